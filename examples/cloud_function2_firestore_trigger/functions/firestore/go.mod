@@ -0,0 +1,21 @@
+module example.com/firestore
+
+go 1.18
+
+require (
+	github.com/GoogleCloudPlatform/functions-framework-go v1.7.1
+	github.com/cloudevents/sdk-go/v2 v2.14.0
+	github.com/googleapis/google-cloudevents-go v0.8.0
+	google.golang.org/protobuf v1.33.0
+)
+
+require (
+	github.com/google/uuid v1.1.2 // indirect
+	github.com/json-iterator/go v1.1.10 // indirect
+	github.com/modern-go/concurrent v0.0.0-20180228061459-e0a39a4cb421 // indirect
+	github.com/modern-go/reflect2 v0.0.0-20180701023420-4b7aa43c6742 // indirect
+	go.uber.org/atomic v1.4.0 // indirect
+	go.uber.org/multierr v1.1.0 // indirect
+	go.uber.org/zap v1.10.0 // indirect
+	google.golang.org/genproto v0.0.0-20231212172506-995d672761c0 // indirect
+)
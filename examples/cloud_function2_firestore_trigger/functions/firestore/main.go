@@ -0,0 +1,46 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package firestore logs the changes of Firestore documents.
+package firestore
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/GoogleCloudPlatform/functions-framework-go/functions"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/googleapis/google-cloudevents-go/cloud/firestoredata"
+	"google.golang.org/protobuf/proto"
+)
+
+func init() {
+	functions.CloudEvent("HelloFirestore", helloFirestore)
+}
+
+// helloFirestore is triggered by a change to a Firestore document.
+// The event data is a DocumentEventData protocol buffer, see the documentation for more details:
+// https://cloud.google.com/eventarc/docs/cloudevents#firestore
+func helloFirestore(ctx context.Context, e event.Event) error {
+	var data firestoredata.DocumentEventData
+	if err := proto.Unmarshal(e.Data(), &data); err != nil {
+		return fmt.Errorf("error unmarshalling the Firestore event: %w", err)
+	}
+
+	log.Printf("Function triggered by change to: %v", e.Subject())
+	log.Printf("Old value: %v", data.GetOldValue().GetFields())
+	log.Printf("New value: %v", data.GetValue().GetFields())
+	return nil
+}
@@ -0,0 +1,83 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+locals {
+  database_name = "function2-firestore-db"
+  collection    = "characters"
+
+  source_dir  = "${path.module}/functions/firestore"
+  source_hash = sha256(join(",", [for f in sort(fileset(local.source_dir, "**")) : "${f}:${filesha256("${local.source_dir}/${f}")}" if length(regexall("\\.zip$", f)) == 0]))
+}
+
+resource "google_storage_bucket" "bucket" {
+  name                        = "${var.project_id}-gcf-source-firestore"
+  location                    = "US"
+  uniform_bucket_level_access = true
+  project                     = var.project_id
+}
+
+data "archive_file" "function_source" {
+  type        = "zip"
+  source_dir  = local.source_dir
+  output_path = "${path.module}/functions/firestore-source.zip"
+}
+
+resource "google_storage_bucket_object" "function-source" {
+  name   = "src-${local.source_hash}.zip"
+  bucket = google_storage_bucket.bucket.name
+  source = data.archive_file.function_source.output_path
+}
+
+resource "google_firestore_database" "database" {
+  project         = var.project_id
+  name            = local.database_name
+  location_id     = var.function_location
+  type            = "FIRESTORE_NATIVE"
+  deletion_policy = "DELETE"
+}
+
+module "cloud_functions2" {
+  source = "../.."
+
+  project_id        = var.project_id
+  function_name     = "function2-firestore-trigger-go"
+  function_location = var.function_location
+  runtime           = "go121"
+  entrypoint        = "HelloFirestore"
+  storage_source = {
+    bucket     = google_storage_bucket.bucket.name
+    object     = google_storage_bucket_object.function-source.name
+    generation = null
+  }
+  event_trigger = {
+    trigger_region        = var.function_location
+    event_type            = "google.cloud.firestore.document.v1.written"
+    service_account_email = null
+    pubsub_topic          = null
+    retry_policy          = "RETRY_POLICY_RETRY"
+    event_filters = [
+      {
+        attribute       = "database"
+        attribute_value = google_firestore_database.database.name
+      },
+      {
+        attribute       = "document"
+        attribute_value = "${local.collection}/{character}"
+        operator        = "match-path-pattern"
+      }
+    ]
+  }
+}
@@ -0,0 +1,50 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+output "function_uri" {
+  description = "URI of the Cloud Function (Gen 2)"
+  value       = module.cloud_functions2.function_uri
+}
+
+output "function_name" {
+  description = "Name of the Cloud Function (Gen 2)"
+  value       = module.cloud_functions2.function_name
+}
+
+output "function_location" {
+  description = "Location of the Cloud Function (Gen 2)"
+  value       = var.function_location
+}
+
+output "database_name" {
+  description = "Name of the Firestore database triggering the Cloud Function"
+  value       = google_firestore_database.database.name
+}
+
+output "document_path_pattern" {
+  description = "Path pattern of the Firestore documents triggering the Cloud Function"
+  value       = "${local.collection}/{character}"
+}
+
+output "project_id" {
+  value       = var.project_id
+  description = "The project ID"
+}
+
+output "source_hash" {
+  value       = local.source_hash
+  description = "The checksum of the Cloud Function source files, used to name the source object."
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package config reads its configuration from a JSON secret mounted as a file.
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/GoogleCloudPlatform/functions-framework-go/functions"
+)
+
+// Config is the JSON document stored in the config secret.
+type Config struct {
+	Greeting string `json:"greeting"`
+	API      struct {
+		Endpoint       string `json:"endpoint"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	} `json:"api"`
+	Features []string `json:"features"`
+}
+
+var (
+	config    Config
+	configErr error
+)
+
+func init() {
+	// The secret is read once per instance, so the requests don't pay for it
+	config, configErr = loadConfig(os.Getenv("CONFIG_PATH"))
+	if configErr != nil {
+		log.Printf("Error loading the config: %v", configErr)
+	}
+
+	functions.HTTP("ShowConfig", showConfig)
+}
+
+// loadConfig reads and unmarshals the JSON config file at path.
+func loadConfig(path string) (Config, error) {
+	var c Config
+	if path == "" {
+		return c, fmt.Errorf("the CONFIG_PATH environment variable is not set")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return c, fmt.Errorf("error reading the config file: %w", err)
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, fmt.Errorf("error parsing the config file: %w", err)
+	}
+	return c, nil
+}
+
+// showConfig answers with the non sensitive settings of the config.
+func showConfig(w http.ResponseWriter, r *http.Request) {
+	if configErr != nil {
+		http.Error(w, "config not available", http.StatusInternalServerError)
+		return
+	}
+
+	fmt.Fprintf(w, "%s! Calling %s with a %ds timeout, features: %v\n", config.Greeting, config.API.Endpoint, config.API.TimeoutSeconds, config.Features)
+}
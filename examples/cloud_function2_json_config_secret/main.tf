@@ -0,0 +1,117 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+locals {
+  function_name     = "function2-json-config-go"
+  config_mount_path = "/etc/config"
+  config_file       = "config.json"
+
+  source_dir  = "${path.module}/functions/config"
+  source_hash = sha256(join(",", [for f in sort(fileset(local.source_dir, "**")) : "${f}:${filesha256("${local.source_dir}/${f}")}" if length(regexall("\\.zip$", f)) == 0]))
+}
+
+resource "google_storage_bucket" "bucket" {
+  name                        = "${var.project_id}-gcf-source-json-config"
+  location                    = "US"
+  uniform_bucket_level_access = true
+  project                     = var.project_id
+}
+
+data "archive_file" "function_source" {
+  type        = "zip"
+  source_dir  = local.source_dir
+  output_path = "${path.module}/functions/config-source.zip"
+}
+
+resource "google_storage_bucket_object" "function-source" {
+  name   = "src-${local.source_hash}.zip"
+  bucket = google_storage_bucket.bucket.name
+  source = data.archive_file.function_source.output_path
+}
+
+// The whole configuration of the function is stored as a single JSON secret
+resource "google_secret_manager_secret" "config" {
+  secret_id = "sct-${local.function_name}-config"
+  project   = var.project_id
+
+  replication {
+    user_managed {
+      replicas {
+        location = var.function_location
+      }
+    }
+  }
+}
+
+resource "google_secret_manager_secret_version" "config" {
+  secret = google_secret_manager_secret.config.id
+  secret_data = jsonencode({
+    greeting = "Hello"
+    api = {
+      endpoint        = "https://api.example.com"
+      timeout_seconds = 10
+    }
+    features = ["audit", "metrics"]
+  })
+}
+
+resource "google_service_account" "function" {
+  project      = var.project_id
+  account_id   = "sa-function2-json-config"
+  display_name = "Service account of ${local.function_name}"
+}
+
+resource "google_secret_manager_secret_iam_member" "config_accessor" {
+  project   = var.project_id
+  secret_id = google_secret_manager_secret.config.secret_id
+  role      = "roles/secretmanager.secretAccessor"
+  member    = "serviceAccount:${google_service_account.function.email}"
+}
+
+module "cloud_functions2" {
+  source = "../.."
+
+  project_id        = var.project_id
+  function_name     = local.function_name
+  function_location = var.function_location
+  runtime           = "go121"
+  entrypoint        = "ShowConfig"
+  storage_source = {
+    bucket     = google_storage_bucket.bucket.name
+    object     = google_storage_bucket_object.function-source.name
+    generation = null
+  }
+  service_config = {
+    service_account_email = google_service_account.function.email
+    runtime_env_variables = {
+      CONFIG_PATH = "${local.config_mount_path}/${local.config_file}"
+    }
+    // The version is pinned, so a config change is rolled out by a new revision instead of reaching running instances
+    secret_volumes = [{
+      mount_path = local.config_mount_path
+      project_id = var.project_id
+      secret     = google_secret_manager_secret.config.secret_id
+      versions = [{
+        version = google_secret_manager_secret_version.config.version
+        path    = local.config_file
+      }]
+    }]
+  }
+
+  depends_on = [
+    google_secret_manager_secret_iam_member.config_accessor
+  ]
+}
@@ -0,0 +1,45 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+output "function_uri" {
+  description = "URI of the Cloud Function (Gen 2)"
+  value       = module.cloud_functions2.function_uri
+}
+
+output "function_name" {
+  description = "Name of the Cloud Function (Gen 2)"
+  value       = module.cloud_functions2.function_name
+}
+
+output "function_location" {
+  description = "Location of the Cloud Function (Gen 2)"
+  value       = var.function_location
+}
+
+output "config_secret_id" {
+  description = "ID of the secret storing the JSON configuration of the Cloud Function"
+  value       = google_secret_manager_secret.config.secret_id
+}
+
+output "config_mount_path" {
+  description = "Directory where the JSON configuration secret is mounted"
+  value       = local.config_mount_path
+}
+
+output "project_id" {
+  value       = var.project_id
+  description = "The project ID"
+}
@@ -14,6 +14,13 @@
  * limitations under the License.
  */
 
+locals {
+  function_name = "function2-pubsub-trigger-py"
+
+  // The only project roles of the function service account
+  runtime_roles = ["roles/logging.logWriter"]
+}
+
 resource "google_storage_bucket" "bucket" {
   name                        = "${var.project_id}-gcf-source-pubsub"
   location                    = "US"
@@ -35,11 +42,30 @@ module "pubsub" {
   project_id = var.project_id
 }
 
+resource "google_service_account" "runtime" {
+  project      = var.project_id
+  account_id   = "sa-function2-pubsub-runtime"
+  display_name = "Runtime service account of ${local.function_name}"
+}
+
+resource "google_project_iam_member" "runtime" {
+  for_each = toset(local.runtime_roles)
+  project  = var.project_id
+  role     = each.value
+  member   = "serviceAccount:${google_service_account.runtime.email}"
+}
+
+resource "google_service_account" "trigger" {
+  project      = var.project_id
+  account_id   = "sa-function2-pubsub-trigger"
+  display_name = "Event trigger service account of ${local.function_name}"
+}
+
 module "cloud_functions2" {
   source = "../.."
 
   project_id        = var.project_id
-  function_name     = "function2-pubsub-trigger-py"
+  function_name     = local.function_name
   function_location = var.function_location
   runtime           = "python38"
   entrypoint        = "hello_http"
@@ -48,12 +74,24 @@ module "cloud_functions2" {
     object     = google_storage_bucket_object.function-source.name
     generation = null
   }
+  service_config = {
+    service_account_email = google_service_account.runtime.email
+  }
   event_trigger = {
     trigger_region        = "us-central1"
     event_type            = "google.cloud.pubsub.topic.v1.messagePublished"
-    service_account_email = null
+    service_account_email = google_service_account.trigger.email
     pubsub_topic          = module.pubsub.id
     retry_policy          = "RETRY_POLICY_RETRY"
     event_filters         = null
   }
 }
+
+// The trigger service account is only allowed to invoke the backing Cloud Run service, with the lowercase function name
+resource "google_cloud_run_service_iam_member" "trigger_invoker" {
+  project  = var.project_id
+  location = var.function_location
+  service  = lower(module.cloud_functions2.function_name)
+  role     = "roles/run.invoker"
+  member   = "serviceAccount:${google_service_account.trigger.email}"
+}
@@ -38,3 +38,13 @@ output "project_id" {
   value       = var.project_id
   description = "The project ID"
 }
+
+output "runtime_service_account_email" {
+  description = "Email of the service account the Cloud Function runs as"
+  value       = google_service_account.runtime.email
+}
+
+output "trigger_service_account_email" {
+  description = "Email of the service account used by the event trigger to invoke the Cloud Function"
+  value       = google_service_account.trigger.email
+}
@@ -0,0 +1,164 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package chain runs the two stages of an order pipeline connected by a Pub/Sub topic.
+//
+// ProcessOrder is triggered by the orders received, and publishes a new
+// structured CloudEvent for each of them to the OUTPUT_TOPIC topic.
+// HandleProcessedOrder is triggered by that topic, and decodes the CloudEvent
+// carried by the Pub/Sub message.
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/GoogleCloudPlatform/functions-framework-go/functions"
+	"github.com/cloudevents/sdk-go/v2/event"
+)
+
+const (
+	// processedOrderType is the type of the CloudEvents published by the first stage.
+	processedOrderType = "com.example.order.processed.v1"
+
+	// processedOrderSource is the source of the CloudEvents published by the first stage.
+	processedOrderSource = "//cloudfunctions.googleapis.com/ProcessOrder"
+)
+
+var (
+	clientOnce sync.Once
+	client     *pubsub.Client
+	clientErr  error
+)
+
+func init() {
+	functions.CloudEvent("ProcessOrder", processOrder)
+	functions.CloudEvent("HandleProcessedOrder", handleProcessedOrder)
+}
+
+// messagePublishedData is the payload of the google.cloud.pubsub.topic.v1.messagePublished events.
+type messagePublishedData struct {
+	Message struct {
+		Data       []byte            `json:"data"`
+		Attributes map[string]string `json:"attributes"`
+		MessageID  string            `json:"messageId"`
+	} `json:"message"`
+}
+
+// order is the JSON message published to the orders-received topic.
+type order struct {
+	ID       string `json:"id"`
+	Quantity int    `json:"quantity"`
+}
+
+// processedOrder is the data of the CloudEvents published by the first stage.
+type processedOrder struct {
+	ID          string    `json:"id"`
+	Quantity    int       `json:"quantity"`
+	ProcessedAt time.Time `json:"processedAt"`
+}
+
+// processOrder is the first stage, publishing a processed order event for each order received.
+func processOrder(ctx context.Context, e event.Event) error {
+	var msg messagePublishedData
+	if err := e.DataAs(&msg); err != nil {
+		return fmt.Errorf("error parsing the Pub/Sub event: %w", err)
+	}
+
+	var o order
+	if err := json.Unmarshal(msg.Message.Data, &o); err != nil {
+		return fmt.Errorf("error parsing the order of message %s: %w", msg.Message.MessageID, err)
+	}
+
+	// The event ID is derived from the message ID, so a redelivered message publishes an event with the same ID
+	// and the consumers can discard the duplicates
+	out := event.New()
+	out.SetID(msg.Message.MessageID)
+	out.SetType(processedOrderType)
+	out.SetSource(processedOrderSource)
+	out.SetSubject("orders/" + o.ID)
+	out.SetTime(time.Now())
+	if err := out.SetData(event.ApplicationJSON, processedOrder{ID: o.ID, Quantity: o.Quantity, ProcessedAt: out.Time()}); err != nil {
+		return fmt.Errorf("error encoding the event of order %s: %w", o.ID, err)
+	}
+	if err := out.Validate(); err != nil {
+		return fmt.Errorf("error validating the event of order %s: %w", o.ID, err)
+	}
+
+	if err := publish(ctx, out); err != nil {
+		return err
+	}
+	log.Printf("Order %s processed, published event %s", o.ID, out.ID())
+	return nil
+}
+
+// handleProcessedOrder is the second stage, triggered by the events published by processOrder.
+func handleProcessedOrder(ctx context.Context, e event.Event) error {
+	var msg messagePublishedData
+	if err := e.DataAs(&msg); err != nil {
+		return fmt.Errorf("error parsing the Pub/Sub event: %w", err)
+	}
+
+	// The message data is a CloudEvent in the structured content mode
+	var in event.Event
+	if err := json.Unmarshal(msg.Message.Data, &in); err != nil {
+		return fmt.Errorf("error parsing the CloudEvent of message %s: %w", msg.Message.MessageID, err)
+	}
+	if err := in.Validate(); err != nil {
+		return fmt.Errorf("error validating the CloudEvent of message %s: %w", msg.Message.MessageID, err)
+	}
+
+	// Events of other types aren't retried, as they would fail again
+	if in.Type() != processedOrderType {
+		log.Printf("Ignoring event %s of type %s", in.ID(), in.Type())
+		return nil
+	}
+
+	var o processedOrder
+	if err := in.DataAs(&o); err != nil {
+		return fmt.Errorf("error parsing the data of event %s: %w", in.ID(), err)
+	}
+	log.Printf("Order %s handled from event %s, processed at %s", o.ID, in.ID(), o.ProcessedAt.Format(time.RFC3339))
+	return nil
+}
+
+// publish sends the event in the structured content mode to the OUTPUT_TOPIC topic.
+func publish(ctx context.Context, e event.Event) error {
+	clientOnce.Do(func() {
+		client, clientErr = pubsub.NewClient(context.Background(), os.Getenv("PROJECT_ID"))
+	})
+	if clientErr != nil {
+		return fmt.Errorf("error creating the Pub/Sub client: %w", clientErr)
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("error encoding event %s: %w", e.ID(), err)
+	}
+
+	result := client.Topic(os.Getenv("OUTPUT_TOPIC")).Publish(ctx, &pubsub.Message{
+		Data:       data,
+		Attributes: map[string]string{"content-type": "application/cloudevents+json"},
+	})
+	if _, err := result.Get(ctx); err != nil {
+		return fmt.Errorf("error publishing event %s: %w", e.ID(), err)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+locals {
+  // The first stage consumes the orders-received topic and publishes a new CloudEvent to the orders-processed topic,
+  // which triggers the second stage
+  stages = {
+    process = {
+      function_name = "function2-chain-process-go"
+      entrypoint    = "ProcessOrder"
+      topic         = "orders-received"
+    }
+    handle = {
+      function_name = "function2-chain-handle-go"
+      entrypoint    = "HandleProcessedOrder"
+      topic         = "orders-processed"
+    }
+  }
+
+  source_dir  = "${path.module}/functions/chain"
+  source_hash = sha256(join(",", [for f in sort(fileset(local.source_dir, "**")) : "${f}:${filesha256("${local.source_dir}/${f}")}" if length(regexall("\\.zip$", f)) == 0]))
+}
+
+resource "google_storage_bucket" "bucket" {
+  name                        = "${var.project_id}-gcf-source-cloudevent-chain"
+  location                    = "US"
+  uniform_bucket_level_access = true
+  project                     = var.project_id
+}
+
+data "archive_file" "function_source" {
+  type        = "zip"
+  source_dir  = local.source_dir
+  output_path = "${path.module}/functions/chain-source.zip"
+}
+
+resource "google_storage_bucket_object" "function-source" {
+  name   = "src-${local.source_hash}.zip"
+  bucket = google_storage_bucket.bucket.name
+  source = data.archive_file.function_source.output_path
+}
+
+resource "google_pubsub_topic" "topic" {
+  for_each = local.stages
+
+  name    = each.value.topic
+  project = var.project_id
+}
+
+resource "google_service_account" "runtime" {
+  for_each = local.stages
+
+  project      = var.project_id
+  account_id   = "sa-chain-${each.key}"
+  display_name = "Runtime service account of ${each.value.function_name}"
+}
+
+resource "google_project_iam_member" "log_writer" {
+  for_each = local.stages
+
+  project = var.project_id
+  role    = "roles/logging.logWriter"
+  member  = "serviceAccount:${google_service_account.runtime[each.key].email}"
+}
+
+// Only the first stage publishes, and only to the topic of the second stage
+resource "google_pubsub_topic_iam_member" "publisher" {
+  project = var.project_id
+  topic   = google_pubsub_topic.topic["handle"].name
+  role    = "roles/pubsub.publisher"
+  member  = "serviceAccount:${google_service_account.runtime["process"].email}"
+}
+
+module "cloud_functions2" {
+  source   = "../.."
+  for_each = local.stages
+
+  project_id        = var.project_id
+  function_name     = each.value.function_name
+  function_location = var.function_location
+  runtime           = "go121"
+  entrypoint        = each.value.entrypoint
+  storage_source = {
+    bucket     = google_storage_bucket.bucket.name
+    object     = google_storage_bucket_object.function-source.name
+    generation = null
+  }
+  service_config = {
+    service_account_email = google_service_account.runtime[each.key].email
+    runtime_env_variables = each.key == "process" ? {
+      PROJECT_ID   = var.project_id
+      OUTPUT_TOPIC = google_pubsub_topic.topic["handle"].name
+    } : null
+  }
+  event_trigger = {
+    trigger_region        = var.function_location
+    event_type            = "google.cloud.pubsub.topic.v1.messagePublished"
+    service_account_email = null
+    pubsub_topic          = google_pubsub_topic.topic[each.key].id
+    retry_policy          = "RETRY_POLICY_RETRY"
+    event_filters         = null
+  }
+
+  depends_on = [google_pubsub_topic_iam_member.publisher]
+}
@@ -0,0 +1,46 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+output "process_function_name" {
+  description = "Name of the Cloud Function (Gen 2) of the first stage, publishing the processed order events"
+  value       = module.cloud_functions2["process"].function_name
+}
+
+output "handle_function_name" {
+  description = "Name of the Cloud Function (Gen 2) of the second stage, consuming the processed order events"
+  value       = module.cloud_functions2["handle"].function_name
+}
+
+output "input_topic" {
+  description = "ID of the Pub/Sub topic triggering the first stage"
+  value       = google_pubsub_topic.topic["process"].id
+}
+
+output "output_topic" {
+  description = "ID of the Pub/Sub topic the first stage publishes to, triggering the second stage"
+  value       = google_pubsub_topic.topic["handle"].id
+}
+
+output "function_location" {
+  description = "Location of the Cloud Functions (Gen 2)"
+  value       = var.function_location
+}
+
+output "project_id" {
+  value       = var.project_id
+  description = "The project ID"
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package orders handles the order events published to Pub/Sub.
+//
+// Several handlers are registered in the same package, and each function
+// deployed from this source runs the one selected by its entry point.
+package orders
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/GoogleCloudPlatform/functions-framework-go/functions"
+	"github.com/cloudevents/sdk-go/v2/event"
+)
+
+func init() {
+	functions.CloudEvent("HandleOrderCreated", handleOrderCreated)
+	functions.CloudEvent("HandleOrderCancelled", handleOrderCancelled)
+}
+
+// messagePublishedData is the payload of the google.cloud.pubsub.topic.v1.messagePublished events.
+type messagePublishedData struct {
+	Message struct {
+		Data       []byte            `json:"data"`
+		Attributes map[string]string `json:"attributes"`
+		MessageID  string            `json:"messageId"`
+	} `json:"message"`
+}
+
+// order is the JSON message published for each order event.
+type order struct {
+	ID     string `json:"id"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// handleOrderCreated is triggered by the messages of the orders-created topic.
+func handleOrderCreated(ctx context.Context, e event.Event) error {
+	o, err := parseOrder(e)
+	if err != nil {
+		return err
+	}
+	log.Printf("Order %s created", o.ID)
+	return nil
+}
+
+// handleOrderCancelled is triggered by the messages of the orders-cancelled topic.
+func handleOrderCancelled(ctx context.Context, e event.Event) error {
+	o, err := parseOrder(e)
+	if err != nil {
+		return err
+	}
+	log.Printf("Order %s cancelled: %s", o.ID, o.Reason)
+	return nil
+}
+
+// parseOrder decodes the order carried by the Pub/Sub message of the event.
+func parseOrder(e event.Event) (order, error) {
+	var msg messagePublishedData
+	if err := e.DataAs(&msg); err != nil {
+		return order{}, fmt.Errorf("error parsing the Pub/Sub event: %w", err)
+	}
+
+	var o order
+	if err := json.Unmarshal(msg.Message.Data, &o); err != nil {
+		return order{}, fmt.Errorf("error parsing the order of message %s: %w", msg.Message.MessageID, err)
+	}
+	return o, nil
+}
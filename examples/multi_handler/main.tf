@@ -0,0 +1,85 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+locals {
+  // Each handler registered in the source is deployed as its own function, selected by the entrypoint
+  handlers = {
+    created = {
+      function_name = "function2-order-created-go"
+      entrypoint    = "HandleOrderCreated"
+      topic         = "orders-created"
+    }
+    cancelled = {
+      function_name = "function2-order-cancelled-go"
+      entrypoint    = "HandleOrderCancelled"
+      topic         = "orders-cancelled"
+    }
+  }
+
+  source_dir  = "${path.module}/functions/orders"
+  source_hash = sha256(join(",", [for f in sort(fileset(local.source_dir, "**")) : "${f}:${filesha256("${local.source_dir}/${f}")}" if length(regexall("\\.zip$", f)) == 0]))
+}
+
+resource "google_storage_bucket" "bucket" {
+  name                        = "${var.project_id}-gcf-source-multi-handler"
+  location                    = "US"
+  uniform_bucket_level_access = true
+  project                     = var.project_id
+}
+
+data "archive_file" "function_source" {
+  type        = "zip"
+  source_dir  = local.source_dir
+  output_path = "${path.module}/functions/orders-source.zip"
+}
+
+// A single source object is uploaded and shared by all the functions
+resource "google_storage_bucket_object" "function-source" {
+  name   = "src-${local.source_hash}.zip"
+  bucket = google_storage_bucket.bucket.name
+  source = data.archive_file.function_source.output_path
+}
+
+resource "google_pubsub_topic" "topic" {
+  for_each = local.handlers
+
+  name    = each.value.topic
+  project = var.project_id
+}
+
+module "cloud_functions2" {
+  source   = "../.."
+  for_each = local.handlers
+
+  project_id        = var.project_id
+  function_name     = each.value.function_name
+  function_location = var.function_location
+  runtime           = "go121"
+  entrypoint        = each.value.entrypoint
+  storage_source = {
+    bucket     = google_storage_bucket.bucket.name
+    object     = google_storage_bucket_object.function-source.name
+    generation = null
+  }
+  event_trigger = {
+    trigger_region        = var.function_location
+    event_type            = "google.cloud.pubsub.topic.v1.messagePublished"
+    service_account_email = null
+    pubsub_topic          = google_pubsub_topic.topic[each.key].id
+    retry_policy          = "RETRY_POLICY_RETRY"
+    event_filters         = null
+  }
+}
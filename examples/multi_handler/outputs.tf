@@ -0,0 +1,40 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+output "created_function_name" {
+  description = "Name of the Cloud Function (Gen 2) running the HandleOrderCreated handler"
+  value       = module.cloud_functions2["created"].function_name
+}
+
+output "cancelled_function_name" {
+  description = "Name of the Cloud Function (Gen 2) running the HandleOrderCancelled handler"
+  value       = module.cloud_functions2["cancelled"].function_name
+}
+
+output "source_object" {
+  description = "Name of the source object shared by the Cloud Functions"
+  value       = google_storage_bucket_object.function-source.name
+}
+
+output "function_location" {
+  description = "Location of the Cloud Functions (Gen 2)"
+  value       = var.function_location
+}
+
+output "project_id" {
+  value       = var.project_id
+  description = "The project ID"
+}
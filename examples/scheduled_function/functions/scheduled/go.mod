@@ -0,0 +1,16 @@
+module example.com/scheduled
+
+go 1.18
+
+require github.com/GoogleCloudPlatform/functions-framework-go v1.7.1
+
+require (
+	github.com/cloudevents/sdk-go/v2 v2.6.1 // indirect
+	github.com/google/uuid v1.1.2 // indirect
+	github.com/json-iterator/go v1.1.10 // indirect
+	github.com/modern-go/concurrent v0.0.0-20180228061459-e0a39a4cb421 // indirect
+	github.com/modern-go/reflect2 v0.0.0-20180701023420-4b7aa43c6742 // indirect
+	go.uber.org/atomic v1.4.0 // indirect
+	go.uber.org/multierr v1.1.0 // indirect
+	go.uber.org/zap v1.10.0 // indirect
+)
@@ -0,0 +1,64 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package scheduled runs a periodic job invoked by Cloud Scheduler.
+package scheduled
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/GoogleCloudPlatform/functions-framework-go/functions"
+)
+
+func init() {
+	functions.HTTP("RunScheduledJob", runScheduledJob)
+}
+
+// runScheduledJob is invoked by a Cloud Scheduler job with an OIDC token.
+// The token is verified by Cloud Run before the request reaches the function, so
+// only the identities granted the Cloud Run Invoker role can run the job.
+func runScheduledJob(w http.ResponseWriter, r *http.Request) {
+	// Cloud Scheduler sets these headers, see the documentation for more details:
+	// https://cloud.google.com/scheduler/docs/reference/rpc/google.cloud.scheduler.v1#httptarget
+	jobName := r.Header.Get("X-CloudScheduler-JobName")
+	scheduleTime := r.Header.Get("X-CloudScheduler-ScheduleTime")
+	if jobName == "" {
+		jobName = "manual"
+	}
+
+	start := time.Now()
+	if err := doPeriodicWork(scheduleTime); err != nil {
+		log.Printf("Job %s failed: %v", jobName, err)
+		// A non 2xx response makes Cloud Scheduler retry the job according to its retry_config
+		http.Error(w, "job failed", http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("Job %s scheduled at %s finished in %s", jobName, scheduleTime, time.Since(start))
+	fmt.Fprintln(w, "OK")
+}
+
+// doPeriodicWork is where the periodic work, like a cleanup or a report, is done.
+func doPeriodicWork(scheduleTime string) error {
+	if scheduleTime == "" {
+		return nil
+	}
+	if _, err := time.Parse(time.RFC3339, scheduleTime); err != nil {
+		return fmt.Errorf("error parsing the schedule time %q: %w", scheduleTime, err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+locals {
+  function_name = "function2-scheduled-go"
+
+  source_dir  = "${path.module}/functions/scheduled"
+  source_hash = sha256(join(",", [for f in sort(fileset(local.source_dir, "**")) : "${f}:${filesha256("${local.source_dir}/${f}")}" if length(regexall("\\.zip$", f)) == 0]))
+}
+
+resource "google_storage_bucket" "bucket" {
+  name                        = "${var.project_id}-gcf-source-scheduled"
+  location                    = "US"
+  uniform_bucket_level_access = true
+  project                     = var.project_id
+}
+
+data "archive_file" "function_source" {
+  type        = "zip"
+  source_dir  = local.source_dir
+  output_path = "${path.module}/functions/scheduled-source.zip"
+}
+
+resource "google_storage_bucket_object" "function-source" {
+  name   = "src-${local.source_hash}.zip"
+  bucket = google_storage_bucket.bucket.name
+  source = data.archive_file.function_source.output_path
+}
+
+module "cloud_functions2" {
+  source = "../.."
+
+  project_id        = var.project_id
+  function_name     = local.function_name
+  function_location = var.function_location
+  runtime           = "go121"
+  entrypoint        = "RunScheduledJob"
+  storage_source = {
+    bucket     = google_storage_bucket.bucket.name
+    object     = google_storage_bucket_object.function-source.name
+    generation = null
+  }
+  service_config = {
+    min_instance_count = "0"
+    ingress_settings   = "ALLOW_ALL"
+  }
+}
+
+resource "google_service_account" "scheduler" {
+  project      = var.project_id
+  account_id   = "sa-function2-scheduler"
+  display_name = "Cloud Scheduler invoker of ${local.function_name}"
+}
+
+// Cloud Functions (2nd Gen) are served by a Cloud Run service with the lowercase function name
+resource "google_cloud_run_service_iam_member" "scheduler_invoker" {
+  project  = var.project_id
+  location = var.function_location
+  service  = lower(module.cloud_functions2.function_name)
+  role     = "roles/run.invoker"
+  member   = "serviceAccount:${google_service_account.scheduler.email}"
+}
+
+resource "google_cloud_scheduler_job" "job" {
+  name             = "job-${local.function_name}"
+  project          = var.project_id
+  region           = var.function_location
+  description      = "Invokes the ${local.function_name} Cloud Function on a schedule."
+  schedule         = var.schedule
+  time_zone        = "Etc/UTC"
+  attempt_deadline = "320s"
+
+  retry_config {
+    retry_count = 1
+  }
+
+  http_target {
+    http_method = "POST"
+    uri         = module.cloud_functions2.function_uri
+
+    oidc_token {
+      service_account_email = google_service_account.scheduler.email
+      audience              = module.cloud_functions2.function_uri
+    }
+  }
+
+  depends_on = [
+    google_cloud_run_service_iam_member.scheduler_invoker
+  ]
+}
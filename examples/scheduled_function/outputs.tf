@@ -0,0 +1,50 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+output "function_uri" {
+  description = "URI of the Cloud Function (Gen 2)"
+  value       = module.cloud_functions2.function_uri
+}
+
+output "function_name" {
+  description = "Name of the Cloud Function (Gen 2)"
+  value       = module.cloud_functions2.function_name
+}
+
+output "function_location" {
+  description = "Location of the Cloud Function (Gen 2)"
+  value       = var.function_location
+}
+
+output "scheduler_job_name" {
+  description = "Name of the Cloud Scheduler job invoking the Cloud Function"
+  value       = google_cloud_scheduler_job.job.name
+}
+
+output "scheduler_service_account_email" {
+  description = "Email of the service account used by the Cloud Scheduler job to invoke the Cloud Function"
+  value       = google_service_account.scheduler.email
+}
+
+output "schedule" {
+  description = "Cron schedule of the Cloud Scheduler job"
+  value       = google_cloud_scheduler_job.job.schedule
+}
+
+output "project_id" {
+  value       = var.project_id
+  description = "The project ID"
+}
@@ -0,0 +1,32 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+variable "project_id" {
+  description = "The ID of the project in which to provision resources."
+  type        = string
+}
+
+variable "function_location" {
+  description = "The location of this cloud function and of the Cloud Scheduler job"
+  type        = string
+  default     = "us-central1"
+}
+
+variable "schedule" {
+  description = "The cron schedule of the Cloud Scheduler job invoking the function"
+  type        = string
+  default     = "*/15 * * * *"
+}
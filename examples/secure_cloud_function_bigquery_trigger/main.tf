@@ -22,6 +22,9 @@ locals {
   table_name      = "tbl_test"
   kms_bigquery    = "key-secure-bigquery"
   subnet_ip       = "10.0.0.0/28"
+
+  source_dir  = "${path.module}/functions/bq-to-cf"
+  source_hash = sha256(join(",", [for f in sort(fileset(local.source_dir, "**")) : "${f}:${filesha256("${local.source_dir}/${f}")}" if length(regexall("\\.zip$", f)) == 0]))
 }
 
 resource "random_id" "random_folder_suffix" {
@@ -98,7 +101,7 @@ resource "google_project_service" "network_project_apis" {
 
 data "archive_file" "cf_bigquery_source" {
   type        = "zip"
-  source_dir  = "${path.module}/functions/bq-to-cf/"
+  source_dir  = local.source_dir
   output_path = "functions/cloudfunction-bq-source-${random_id.random_folder_suffix.hex}.zip"
 }
 
@@ -106,9 +109,9 @@ resource "google_storage_bucket_object" "cf_bigquery_source_zip" {
   source       = data.archive_file.cf_bigquery_source.output_path
   content_type = "application/zip"
 
-  # Append to the MD5 checksum of the files's content
+  # Append to the checksum of the files's content
   # to force the zip to be updated as soon as a change occurs
-  name   = "src-${data.archive_file.cf_bigquery_source.output_md5}.zip"
+  name   = "src-${local.source_hash}.zip"
   bucket = module.cloudfunction_source_bucket.name
 
   depends_on = [
@@ -102,3 +102,8 @@ output "cloudfunction_url" {
   value       = module.secure_cloud_function.cloudfunction_url
   description = "The URL on which the deployed service is available."
 }
+
+output "source_hash" {
+  value       = local.source_hash
+  description = "The checksum of the Cloud Function source files, used to name the source object."
+}
@@ -0,0 +1,40 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudpostgres
+
+import (
+	"fmt"
+	"testing"
+
+	"example.com/shared/retry"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// The errors of pgx are classified by their SQLState method, which the shared classifier matches without importing pgx.
+func TestPgErrors(t *testing.T) {
+	if retry.IsTransient(fmt.Errorf("error selecting from table: %w", &pgconn.PgError{Code: "42P01"}), retry.PostgreSQL) {
+		t.Error("a missing table is transient, want permanent")
+	}
+	if !retry.IsTransient(&pgconn.PgError{Code: "57P01"}, retry.PostgreSQL) {
+		t.Error("an administrator shutdown is permanent, want transient")
+	}
+}
+
+func TestConfigErrorsArePermanent(t *testing.T) {
+	t.Setenv("DB_CONNECT_TIMEOUT_SECONDS", "soon")
+	if _, err := positiveIntEnv("DB_CONNECT_TIMEOUT_SECONDS", 10); err == nil || retry.IsTransient(err, retry.PostgreSQL) {
+		t.Errorf("positiveIntEnv() error = %v, want a permanent error", err)
+	}
+}
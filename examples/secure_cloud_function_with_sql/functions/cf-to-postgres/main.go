@@ -0,0 +1,210 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cloudpostgres is the PostgreSQL variant of the cf-to-sql function.
+//
+// It connects to a Cloud SQL for PostgreSQL instance the same way cf-to-sql
+// connects to the MySQL instance: the Cloud SQL Go connector dials the private
+// IP of the instance with mTLS, verifying the server certificate, and the
+// function logs in with a password or, with DB_IAM_AUTH, with IAM database
+// authentication.
+package cloudpostgres
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	// Pre importing this dependency because there is a redirect that doesn't work with Secure Web Proxy
+	_ "golang.org/x/sync/errgroup"
+
+	"cloud.google.com/go/cloudsqlconn"
+	"example.com/shared/logging"
+	"example.com/shared/retry"
+	"github.com/GoogleCloudPlatform/functions-framework-go/functions"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// defaultConnectTimeout bounds the connection and the query when DB_CONNECT_TIMEOUT_SECONDS is not set,
+// so a database outage fails the invocation quickly instead of hanging until the function timeout.
+const defaultConnectTimeout = 10 * time.Second
+
+// The connection pool settings when DB_MAX_OPEN_CONNS and DB_CONN_MAX_LIFETIME_SECONDS are not set.
+const (
+	defaultMaxOpenConns    = 5
+	defaultConnMaxLifetime = 30 * time.Minute
+)
+
+// dbPool is the connection pool shared by the invocations of a warm instance of the function, nil until a pool is
+// created. poolMu serializes its creation by concurrent invocations.
+var (
+	poolMu sync.Mutex
+	dbPool *pgxpool.Pool
+)
+
+func init() {
+	functions.CloudEvent("HelloCloudFunction", connect)
+}
+
+// connect processes the event, returning the transient errors so Eventarc retries the event, and acknowledging the
+// events which fail with a permanent error, which would fail again on every retry until the retry period expires.
+func connect(ctx context.Context, e event.Event) error {
+	ctx = logging.ForEvent(ctx, e)
+	err := process(ctx)
+	if err != nil && !retry.IsTransient(err, retry.PostgreSQL) {
+		logging.Error(ctx, "Permanent error, acknowledging the event: %v", err)
+		return nil
+	}
+	return err
+}
+
+func process(ctx context.Context) error {
+	timeout, err := positiveIntEnv("DB_CONNECT_TIMEOUT_SECONDS", int(defaultConnectTimeout/time.Second))
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	pool, err := openPool(ctx)
+	if err != nil {
+		return err
+	}
+
+	var (
+		id          int
+		name        string
+		performance string
+	)
+
+	logging.Info(ctx, "Select from table.")
+	rows, err := pool.Query(ctx, "SELECT id, name, performance FROM characters")
+	if err != nil {
+		return fmt.Errorf("error selecting from table: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.Scan(&id, &name, &performance); err != nil {
+			return fmt.Errorf("error reading row: %w", err)
+		}
+		logging.Info(ctx, "%v: %s: %s", id, name, performance)
+	}
+
+	return rows.Err()
+}
+
+// openPool creates the connection pool on the first invocation of the instance of the function, and returns the same
+// pool to the next ones. The connections are opened by the pool when they are needed. A failure to create the dialer or
+// the pool is not kept, the next invocation creating them again.
+func openPool(ctx context.Context) (*pgxpool.Pool, error) {
+	poolMu.Lock()
+	defer poolMu.Unlock()
+
+	if dbPool != nil {
+		return dbPool, nil
+	}
+	pool, err := newPool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dbPool = pool
+	return pool, nil
+}
+
+func newPool(ctx context.Context) (*pgxpool.Pool, error) {
+	instanceUser := os.Getenv("INSTANCE_USER")
+	instancePWD := os.Getenv("INSTANCE_PWD")
+	databaseName := os.Getenv("DATABASE_NAME")
+
+	// The connection name of the instance, project:region:instance, identifies it to the connector library
+	instanceConnectionName := os.Getenv("INSTANCE_CONNECTION_NAME")
+	if instanceConnectionName == "" {
+		return nil, retry.Permanent(fmt.Errorf("INSTANCE_CONNECTION_NAME must be set to the connection name of the instance"))
+	}
+
+	maxOpenConns, err := positiveIntEnv("DB_MAX_OPEN_CONNS", defaultMaxOpenConns)
+	if err != nil {
+		return nil, err
+	}
+	lifetime, err := positiveIntEnv("DB_CONN_MAX_LIFETIME_SECONDS", int(defaultConnMaxLifetime/time.Second))
+	if err != nil {
+		return nil, err
+	}
+
+	// With DB_IAM_AUTH set to true, the function logs in as the IAM database user of its service account, INSTANCE_USER
+	// being the email of the service account without the .gserviceaccount.com suffix, and INSTANCE_PWD is not used.
+	// The OAuth2 token of the service account is refreshed by the connector along with its certificates.
+	iamAuth := os.Getenv("DB_IAM_AUTH") == "true"
+	opts := []cloudsqlconn.Option{
+		cloudsqlconn.WithDefaultDialOptions(
+			cloudsqlconn.WithPrivateIP(),
+		),
+	}
+	if iamAuth {
+		opts = append(opts, cloudsqlconn.WithIAMAuthN())
+	}
+
+	// The credentials are set on the parsed config rather than in the connection string, so a password with spaces,
+	// quotes or backslashes doesn't need to be escaped
+	config, err := pgxpool.ParseConfig("sslmode=disable")
+	if err != nil {
+		return nil, retry.Permanent(fmt.Errorf("error parsing the connection config: %w", err))
+	}
+	config.ConnConfig.User = instanceUser
+	config.ConnConfig.Database = databaseName
+	if !iamAuth {
+		config.ConnConfig.Password = instancePWD
+	}
+	config.MaxConns = int32(maxOpenConns)
+	config.MaxConnLifetime = time.Duration(lifetime) * time.Second
+
+	// The dialer is created once and shared by the connections of the pool, so its ephemeral certificates are cached
+	// and refreshed in the background before they expire. The connections are encrypted by the connector, which is why
+	// pgx doesn't negotiate TLS itself with sslmode=disable.
+	d, err := cloudsqlconn.NewDialer(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating new Dialer: %w", err)
+	}
+	config.ConnConfig.DialFunc = func(ctx context.Context, _ string, _ string) (net.Conn, error) {
+		return d.Dial(ctx, instanceConnectionName)
+	}
+
+	logging.Info(ctx, "Open connection pool.")
+	pool, err := pgxpool.NewWithConfig(context.Background(), config)
+	if err != nil {
+		d.Close()
+		return nil, fmt.Errorf("error connecting to data base: %w", err)
+	}
+	return pool, nil
+}
+
+// positiveIntEnv reads a positive integer environment variable, returning defaultValue when it is not set.
+func positiveIntEnv(name string, defaultValue int) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, retry.Permanent(fmt.Errorf("invalid %s %q: must be a positive integer", name, value))
+	}
+	return n, nil
+}
@@ -0,0 +1,38 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudpostgres
+
+import (
+	"context"
+	"testing"
+)
+
+// A configuration fixed between two invocations of a warm instance must be picked up, so openPool must not cache
+// the pool of a failed attempt.
+func TestOpenPoolAfterFailure(t *testing.T) {
+	t.Setenv("INSTANCE_CONNECTION_NAME", "")
+	if _, err := openPool(context.Background()); err == nil {
+		t.Fatal("openPool() error = nil without INSTANCE_CONNECTION_NAME")
+	}
+
+	t.Setenv("INSTANCE_CONNECTION_NAME", "project:region:instance")
+	t.Setenv("DB_MAX_OPEN_CONNS", "none")
+	if _, err := openPool(context.Background()); err == nil {
+		t.Fatal("openPool() error = nil with an invalid DB_MAX_OPEN_CONNS")
+	}
+	if dbPool != nil {
+		t.Errorf("dbPool = %v after failed attempts, want nil", dbPool)
+	}
+}
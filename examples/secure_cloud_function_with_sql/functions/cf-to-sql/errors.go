@@ -0,0 +1,48 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudsql
+
+import (
+	"database/sql/driver"
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// MySQL server errors which fail again when the event is retried, see
+// https://dev.mysql.com/doc/mysql-errors/8.0/en/server-error-reference.html.
+var permanentMySQLErrors = map[uint16]bool{
+	1044: true, // ER_DBACCESS_DENIED_ERROR
+	1045: true, // ER_ACCESS_DENIED_ERROR
+	1049: true, // ER_BAD_DB_ERROR
+	1054: true, // ER_BAD_FIELD_ERROR
+	1064: true, // ER_PARSE_ERROR
+	1142: true, // ER_TABLEACCESS_DENIED_ERROR
+	1146: true, // ER_NO_SUCH_TABLE
+}
+
+// mysqlErrors is the retry.Classifier of the errors of the MySQL driver. A broken connection is retried, the pool
+// opening a new one, like the server errors which aren't known to fail again.
+func mysqlErrors(err error) (permanent, ok bool) {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, mysql.ErrInvalidConn) {
+		return false, true
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return permanentMySQLErrors[mysqlErr.Number], true
+	}
+	return false, false
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudsql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	"example.com/shared/retry"
+	"github.com/go-sql-driver/mysql"
+)
+
+func TestMySQLErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"ping retries timed out", fmt.Errorf("%w, last error: %v", context.DeadlineExceeded, errors.New("connection refused")), true},
+		{"bad connection", driver.ErrBadConn, true},
+		{"invalid connection", mysql.ErrInvalidConn, true},
+		{"too many connections", &mysql.MySQLError{Number: 1040, Message: "Too many connections"}, true},
+		{"deadlock", fmt.Errorf("error selecting from table: %w", &mysql.MySQLError{Number: 1213, Message: "Deadlock found"}), true},
+		{"missing table", fmt.Errorf("error selecting from table: %w", &mysql.MySQLError{Number: 1146, Message: "Table 'db.characters' doesn't exist"}), false},
+		{"bad SQL", &mysql.MySQLError{Number: 1064, Message: "You have an error in your SQL syntax"}, false},
+		{"unknown column", &mysql.MySQLError{Number: 1054, Message: "Unknown column 'name'"}, false},
+		{"access denied", fmt.Errorf("error during ping: %w", &mysql.MySQLError{Number: 1045, Message: "Access denied"}), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retry.IsTransient(tt.err, mysqlErrors); got != tt.want {
+				t.Errorf("retry.IsTransient(%v, mysqlErrors) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigErrorsArePermanent(t *testing.T) {
+	t.Setenv("DB_CONNECT_TIMEOUT_SECONDS", "soon")
+	if _, err := connectTimeout(); err == nil || retry.IsTransient(err, mysqlErrors) {
+		t.Errorf("connectTimeout() error = %v, want a permanent error", err)
+	}
+
+	t.Setenv("DB_CONNECT_MAX_RETRIES", "-1")
+	if _, err := connectMaxRetries(); err == nil || retry.IsTransient(err, mysqlErrors) {
+		t.Errorf("connectMaxRetries() error = %v, want a permanent error", err)
+	}
+
+	t.Setenv("DB_MAX_OPEN_CONNS", "0")
+	if _, _, _, err := poolSettings(); err == nil || retry.IsTransient(err, mysqlErrors) {
+		t.Errorf("poolSettings() error = %v, want a permanent error", err)
+	}
+}
@@ -3,15 +3,34 @@ module example.com/cloudsql
 go 1.18
 
 require (
-	cloud.google.com/go/cloudsqlconn v1.2.3 // indirect
+	cloud.google.com/go/cloudsqlconn v1.2.3
+	cloud.google.com/go/compute/metadata v0.2.3
+	cloud.google.com/go/secretmanager v1.10.0
+	example.com/shared v0.0.0
+	github.com/GoogleCloudPlatform/functions-framework-go v1.7.1
+	github.com/GoogleCloudPlatform/opentelemetry-operations-go/exporter/metric v0.36.0
+	github.com/GoogleCloudPlatform/opentelemetry-operations-go/exporter/trace v1.13.0
+	github.com/cloudevents/sdk-go/v2 v2.14.0
+	github.com/go-sql-driver/mysql v1.7.1
+	go.opentelemetry.io/otel v1.14.0
+	go.opentelemetry.io/otel/metric v0.36.0
+	go.opentelemetry.io/otel/sdk v1.14.0
+	go.opentelemetry.io/otel/sdk/metric v0.36.0
+	go.opentelemetry.io/otel/trace v1.14.0
+	golang.org/x/sync v0.1.0
+)
+
+require (
 	cloud.google.com/go/compute v1.19.0 // indirect
-	cloud.google.com/go/compute/metadata v0.2.3 // indirect
-	github.com/GoogleCloudPlatform/functions-framework-go v1.7.1 // indirect
-	github.com/cloudevents/sdk-go/v2 v2.14.0 // indirect
-	github.com/go-sql-driver/mysql v1.7.1 // indirect
-	github.com/cloudevents/sdk-go/v2 v2.5.0
-	github.com/golang/groupcache v0.0.0-20200121045136-8c9f03a8e57e // indirect
+	cloud.google.com/go/iam v0.13.0 // indirect
+	cloud.google.com/go/monitoring v1.13.0 // indirect
+	cloud.google.com/go/trace v1.9.0 // indirect
+	github.com/GoogleCloudPlatform/opentelemetry-operations-go/internal/resourcemapping v0.37.0 // indirect
+	github.com/go-logr/logr v1.2.3 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/golang/groupcache v0.0.0-20210331224755-41bb18bfe9da // indirect
 	github.com/golang/protobuf v1.5.3 // indirect
+	github.com/google/go-cmp v0.5.9 // indirect
 	github.com/google/s2a-go v0.1.0 // indirect
 	github.com/google/uuid v1.3.0 // indirect
 	github.com/googleapis/enterprise-certificate-proxy v0.2.3 // indirect
@@ -20,8 +39,8 @@ require (
 	github.com/modern-go/concurrent v0.0.0-20180228061459-e0a39a4cb421 // indirect
 	github.com/modern-go/reflect2 v0.0.0-20180701023420-4b7aa43c6742 // indirect
 	go.opencensus.io v0.24.0 // indirect
-	go.uber.org/atomic v1.4.0 // indirect
-	go.uber.org/multierr v1.1.0 // indirect
+	go.uber.org/atomic v1.10.0 // indirect
+	go.uber.org/multierr v1.8.0 // indirect
 	go.uber.org/zap v1.10.0 // indirect
 	golang.org/x/crypto v0.6.0 // indirect
 	golang.org/x/net v0.9.0 // indirect
@@ -35,3 +54,6 @@ require (
 	google.golang.org/grpc v1.54.0 // indirect
 	google.golang.org/protobuf v1.30.0 // indirect
 )
+
+// The source archive built by the example vendors the shared module under shared/ and points this directive to it
+replace example.com/shared => ../shared
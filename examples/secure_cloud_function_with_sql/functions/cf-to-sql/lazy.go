@@ -0,0 +1,44 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudsql
+
+import "sync"
+
+// lazy holds a value initialized on the first successful call of get. A failed initialization isn't kept, and is
+// attempted again by the next call, so a transient failure at the cold start, like the metadata server not being
+// ready yet, doesn't fail all the invocations of the instance of the function.
+type lazy[T any] struct {
+	mu    sync.Mutex
+	done  bool
+	value T
+}
+
+// get returns the value, calling init to initialize it when no previous call succeeded.
+func (l *lazy[T]) get(init func() (T, error)) (T, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.done {
+		return l.value, nil
+	}
+
+	value, err := init()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	l.value, l.done = value, true
+	return value, nil
+}
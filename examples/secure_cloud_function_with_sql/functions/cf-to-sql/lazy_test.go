@@ -0,0 +1,49 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudsql
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLazyRetriesFailedInit(t *testing.T) {
+	var l lazy[int]
+	calls := 0
+	init := func() (int, error) {
+		calls++
+		if calls == 1 {
+			return 0, errors.New("metadata server not ready")
+		}
+		return 42, nil
+	}
+
+	if _, err := l.get(init); err == nil {
+		t.Fatal("get() error = nil, want the error of the first init")
+	}
+
+	value, err := l.get(init)
+	if err != nil || value != 42 {
+		t.Fatalf("get() = %v, %v, want 42 from the retried init", value, err)
+	}
+
+	value, err = l.get(init)
+	if err != nil || value != 42 {
+		t.Fatalf("get() = %v, %v, want the cached 42", value, err)
+	}
+	if calls != 2 {
+		t.Errorf("init called %d times, want 2: once failing and once succeeding", calls)
+	}
+}
@@ -18,63 +18,129 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"log"
+	"math/rand"
 	"net"
 	"os"
+	"strconv"
+	"time"
 
 	// Pre importing this dependency because there is a redirect that doesn't work with Secure Web Proxy
 	_ "golang.org/x/sync/errgroup"
 
 	"cloud.google.com/go/cloudsqlconn"
+	"example.com/shared/logging"
+	"example.com/shared/retry"
 	"github.com/GoogleCloudPlatform/functions-framework-go/functions"
 	"github.com/cloudevents/sdk-go/v2/event"
 	"github.com/go-sql-driver/mysql"
 )
 
+// defaultConnectTimeout bounds the connection and the query when DB_CONNECT_TIMEOUT_SECONDS is not set,
+// so a database outage fails the invocation quickly instead of hanging until the function timeout.
+const defaultConnectTimeout = 10 * time.Second
+
+// defaultConnectMaxRetries is the number of connection retries when DB_CONNECT_MAX_RETRIES is not set.
+// The retries use an exponential backoff starting at connectBaseBackoff and capped at connectMaxBackoff,
+// with full jitter, and are bounded by the connect timeout as well.
+const (
+	defaultConnectMaxRetries = 3
+	connectBaseBackoff       = 500 * time.Millisecond
+	connectMaxBackoff        = 5 * time.Second
+)
+
+// The connection pool settings when DB_MAX_OPEN_CONNS, DB_MAX_IDLE_CONNS and DB_CONN_MAX_LIFETIME_SECONDS are not set.
+// The connections are closed before the instance can restart them, so the pool doesn't hand out broken connections.
+const (
+	defaultMaxOpenConns    = 5
+	defaultMaxIdleConns    = 2
+	defaultConnMaxLifetime = 30 * time.Minute
+)
+
+var (
+	// dialer dials the Cloud SQL instance, for the connections of the pool.
+	dialer lazy[*cloudsqlconn.Dialer]
+
+	// dbPool is the connection pool shared by the invocations of a warm instance of the function.
+	dbPool lazy[*sql.DB]
+)
+
 func init() {
 	functions.CloudEvent("HelloCloudFunction", connect)
 }
 
-func connect(ctx context.Context, e event.Event) error {
-	instanceProjectID := os.Getenv("INSTANCE_PROJECT_ID")
-	instanceUser := os.Getenv("INSTANCE_USER")
-	instancePWD := os.Getenv("INSTANCE_PWD")
-	instanceLocation := os.Getenv("INSTANCE_LOCATION")
-	instanceName := os.Getenv("INSTANCE_NAME")
-	databaseName := os.Getenv("DATABASE_NAME")
+// registerDialer registers the cloudsqlconn network of the MySQL driver, dialing the instance connection name of the
+// DSN with the connector library. The dialer is created once per instance of the function and reused by the
+// invocations, so the ephemeral certificates of the mTLS connections are cached and refreshed in the background before
+// they expire, and there is no server certificate to mount. The private IP of the instance is dialed by default.
+//
+// With iamAuth, the OAuth2 token of the service account is embedded in the ephemeral certificates as well, and
+// refreshed with them, so the new connections of a long-lived pool keep logging in after the token expires.
+func registerDialer(iamAuth bool) error {
+	_, err := dialer.get(func() (*cloudsqlconn.Dialer, error) {
+		opts := []cloudsqlconn.Option{
+			cloudsqlconn.WithDefaultDialOptions(
+				cloudsqlconn.WithPrivateIP(),
+			),
+		}
+		if iamAuth {
+			opts = append(opts, cloudsqlconn.WithIAMAuthN())
+		}
 
-	d, err := cloudsqlconn.NewDialer(
-		ctx,
-		cloudsqlconn.WithDefaultDialOptions(
-			cloudsqlconn.WithPrivateIP(),
-		),
-	)
-	if err != nil {
-		log.Fatal(err)
-		fmt.Errorf("Error creating new Dialer", err)
+		d, err := cloudsqlconn.NewDialer(context.Background(), opts...)
+		if err != nil {
+			return nil, fmt.Errorf("error creating new Dialer: %w", err)
+		}
+
+		logging.Info(context.Background(), "Registering Driver.")
+		mysql.RegisterDialContext("cloudsqlconn",
+			func(ctx context.Context, addr string) (net.Conn, error) {
+				return d.Dial(ctx, addr)
+			})
+		return d, nil
+	})
+	return err
+}
+
+// connect processes the event, returning the transient errors so Eventarc retries the event, and acknowledging the
+// events which fail with a permanent error, which would fail again on every retry until the retry period expires.
+func connect(ctx context.Context, e event.Event) error {
+	err := process(ctx, e)
+	if err != nil && !retry.IsTransient(err, mysqlErrors) {
+		logging.Error(logging.ForEvent(ctx, e), "Permanent error, acknowledging the event: %v", err)
+		return nil
 	}
+	return err
+}
 
-	instanceConnectionName := fmt.Sprintf("%s:%s:%s", instanceProjectID, instanceLocation, instanceName)
+func process(ctx context.Context, e event.Event) (err error) {
+	ctx, span := tracer.Start(logging.ForEvent(ctx, e), "HelloCloudFunction")
+	defer flushSpans()
+	defer func() { endSpan(span, err) }()
+	defer flushMetrics()
+	defer func() { recordEvent(err) }()
 
-	fmt.Println("Registering Driver.")
-	mysql.RegisterDialContext("cloudsqlconn",
-		func(ctx context.Context, addr string) (net.Conn, error) {
-			return d.Dial(ctx, instanceConnectionName)
-		})
+	timeout, err := connectTimeout()
+	if err != nil {
+		return err
+	}
+	maxRetries, err := connectMaxRetries()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	fmt.Println("Open connection.")
-	db, err := sql.Open(
-		"mysql",
-		fmt.Sprintf("%s:%s@cloudsqlconn(%s)/%s", instanceUser, instancePWD, instanceConnectionName, databaseName),
-	)
+	db, err := openDB(ctx)
 	if err != nil {
-		log.Fatal(err)
-		fmt.Errorf("Error connecting to data base.", err)
+		return err
 	}
-	err = db.Ping()
+
+	// The ping reuses an idle connection of the pool on a warm instance, and only dials the instance when there is none
+	_, connectSpan := tracer.Start(ctx, "db.connect")
+	err = pingWithRetries(ctx, db, maxRetries)
+	endSpan(connectSpan, err)
 	if err != nil {
-		log.Fatal(err)
-		fmt.Errorf("Error during ping.", err)
+		return fmt.Errorf("error during ping: %w", err)
 	}
 
 	var (
@@ -83,16 +149,197 @@ func connect(ctx context.Context, e event.Event) error {
 		performance string
 	)
 
-	fmt.Println("Select from table.")
-	res, err := db.Query("SELECT * FROM characters")
+	logging.Info(ctx, "Select from table.")
+	_, querySpan := tracer.Start(ctx, "db.query")
+	defer func() { endSpan(querySpan, err) }()
+
+	queryStart := time.Now()
+	res, err := db.QueryContext(ctx, "SELECT * FROM characters")
+	recordQueryLatency(time.Since(queryStart), err)
+	if err != nil {
+		return fmt.Errorf("error selecting from table: %w", err)
+	}
+	defer res.Close()
 
 	for res.Next() {
-		err := res.Scan(&id, &name, &performance)
+		if err := res.Scan(&id, &name, &performance); err != nil {
+			return fmt.Errorf("error reading row: %w", err)
+		}
+		logging.Info(ctx, "%v: %s: %s", id, name, performance)
+	}
+
+	return res.Err()
+}
+
+// openDB opens the connection pool on the first invocation of the instance of the function, and returns the same pool
+// to the next ones. The pool is never closed, its connections being closed with the instance. A failure to open it is
+// not kept, the next invocation opening it again.
+func openDB(ctx context.Context) (*sql.DB, error) {
+	return dbPool.get(func() (*sql.DB, error) {
+		maxOpenConns, maxIdleConns, connMaxLifetime, err := poolSettings()
+		if err != nil {
+			return nil, err
+		}
+
+		dsn, err := dataSourceName()
 		if err != nil {
-			log.Fatal(err)
+			return nil, err
 		}
-		fmt.Println(fmt.Sprintf("%v: %s: %s", id, name, performance))
+
+		// With SECRET_NAME, the password is read from the secret version at the cold start, and by the connections
+		// opened after the database rejected it, instead of INSTANCE_PWD
+		var pool *sql.DB
+		if secretVersion := os.Getenv("SECRET_NAME"); secretVersion != "" {
+			cfg, err := mysql.ParseDSN(dsn)
+			if err != nil {
+				return nil, retry.Permanent(fmt.Errorf("error parsing the DSN: %w", err))
+			}
+			if _, err := secretPassword(ctx, secretVersion, false); err != nil {
+				return nil, err
+			}
+
+			logging.Info(ctx, "Open connection pool.")
+			pool = sql.OpenDB(&secretConnector{cfg: cfg, secretVersion: secretVersion})
+		} else {
+			logging.Info(ctx, "Open connection pool.")
+			pool, err = sql.Open("mysql", dsn)
+			if err != nil {
+				return nil, fmt.Errorf("error connecting to data base: %w", err)
+			}
+		}
+		pool.SetMaxOpenConns(maxOpenConns)
+		pool.SetMaxIdleConns(maxIdleConns)
+		pool.SetConnMaxLifetime(connMaxLifetime)
+		return pool, nil
+	})
+}
+
+// dataSourceName builds the DSN of the database from the environment variables.
+func dataSourceName() (string, error) {
+	instanceProjectID := os.Getenv("INSTANCE_PROJECT_ID")
+	instanceUser := os.Getenv("INSTANCE_USER")
+	instancePWD := os.Getenv("INSTANCE_PWD")
+	instanceLocation := os.Getenv("INSTANCE_LOCATION")
+	instanceName := os.Getenv("INSTANCE_NAME")
+	databaseName := os.Getenv("DATABASE_NAME")
+
+	// The connection name of the instance, project:region:instance, identifies it to the connector library.
+	// It is built from the project, location and name of the instance when INSTANCE_CONNECTION_NAME is not set.
+	instanceConnectionName := os.Getenv("INSTANCE_CONNECTION_NAME")
+	if instanceConnectionName == "" {
+		instanceConnectionName = fmt.Sprintf("%s:%s:%s", instanceProjectID, instanceLocation, instanceName)
 	}
 
-	return err
+	// The connector library dials the private IP of the instance, through the VPC Connector of the function.
+	// The Unix socket of a Cloud SQL instance attached to the function with cloudsql_instances is used instead
+	// when INSTANCE_UNIX_SOCKET is set, for instances reachable without the VPC.
+	//
+	// With DB_IAM_AUTH set to true, the function logs in as the IAM database user of its service account, INSTANCE_USER
+	// being the email of the service account without the domain, and INSTANCE_PWD is not used.
+	iamAuth := os.Getenv("DB_IAM_AUTH") == "true"
+	if iamAuth && os.Getenv("SECRET_NAME") != "" {
+		return "", retry.Permanent(fmt.Errorf("DB_IAM_AUTH doesn't use a password and can't be used with SECRET_NAME"))
+	}
+
+	var dsn string
+	if socket := os.Getenv("INSTANCE_UNIX_SOCKET"); socket != "" {
+		if iamAuth {
+			return "", retry.Permanent(fmt.Errorf("DB_IAM_AUTH requires the connector library and can't be used with INSTANCE_UNIX_SOCKET"))
+		}
+		dsn = fmt.Sprintf("%s:%s@unix(%s)/%s", instanceUser, instancePWD, socket, databaseName)
+	} else {
+		if err := registerDialer(iamAuth); err != nil {
+			return "", err
+		}
+		if iamAuth {
+			dsn = fmt.Sprintf("%s@cloudsqlconn(%s)/%s", instanceUser, instanceConnectionName, databaseName)
+		} else {
+			dsn = fmt.Sprintf("%s:%s@cloudsqlconn(%s)/%s", instanceUser, instancePWD, instanceConnectionName, databaseName)
+		}
+	}
+
+	return dsn, nil
+}
+
+// pingWithRetries pings the database, retrying up to maxRetries times with an exponential backoff and jitter,
+// so restarts of the Cloud SQL instance, like during a maintenance, don't fail the invocation.
+func pingWithRetries(ctx context.Context, db *sql.DB, maxRetries int) error {
+	backoff := connectBaseBackoff
+	for attempt := 0; ; attempt++ {
+		err := db.PingContext(ctx)
+		if err == nil || attempt >= maxRetries {
+			return err
+		}
+
+		// Full jitter spreads the retries of the instances restarted at the same time
+		wait := time.Duration(rand.Int63n(int64(backoff)) + 1)
+		logging.Warning(ctx, "Ping failed, retrying in %s: %v", wait, err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w, last error: %v", ctx.Err(), err)
+		case <-time.After(wait):
+		}
+
+		backoff *= 2
+		if backoff > connectMaxBackoff {
+			backoff = connectMaxBackoff
+		}
+	}
+}
+
+// connectMaxRetries reads the DB_CONNECT_MAX_RETRIES environment variable.
+func connectMaxRetries() (int, error) {
+	value := os.Getenv("DB_CONNECT_MAX_RETRIES")
+	if value == "" {
+		return defaultConnectMaxRetries, nil
+	}
+
+	retries, err := strconv.Atoi(value)
+	if err != nil || retries < 0 {
+		return 0, retry.Permanent(fmt.Errorf("invalid DB_CONNECT_MAX_RETRIES %q: must be a non-negative integer", value))
+	}
+	return retries, nil
+}
+
+// connectTimeout reads the DB_CONNECT_TIMEOUT_SECONDS environment variable.
+func connectTimeout() (time.Duration, error) {
+	value := os.Getenv("DB_CONNECT_TIMEOUT_SECONDS")
+	if value == "" {
+		return defaultConnectTimeout, nil
+	}
+
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds <= 0 {
+		return 0, retry.Permanent(fmt.Errorf("invalid DB_CONNECT_TIMEOUT_SECONDS %q: must be a positive integer", value))
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// poolSettings reads the DB_MAX_OPEN_CONNS, DB_MAX_IDLE_CONNS and DB_CONN_MAX_LIFETIME_SECONDS environment variables.
+func poolSettings() (maxOpenConns, maxIdleConns int, connMaxLifetime time.Duration, err error) {
+	if maxOpenConns, err = positiveIntEnv("DB_MAX_OPEN_CONNS", defaultMaxOpenConns); err != nil {
+		return 0, 0, 0, err
+	}
+	if maxIdleConns, err = positiveIntEnv("DB_MAX_IDLE_CONNS", defaultMaxIdleConns); err != nil {
+		return 0, 0, 0, err
+	}
+	lifetime, err := positiveIntEnv("DB_CONN_MAX_LIFETIME_SECONDS", int(defaultConnMaxLifetime/time.Second))
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return maxOpenConns, maxIdleConns, time.Duration(lifetime) * time.Second, nil
+}
+
+// positiveIntEnv reads a positive integer environment variable, returning defaultValue when it is not set.
+func positiveIntEnv(name string, defaultValue int) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, retry.Permanent(fmt.Errorf("invalid %s %q: must be a positive integer", name, value))
+	}
+	return n, nil
 }
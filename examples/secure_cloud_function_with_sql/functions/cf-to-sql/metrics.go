@@ -0,0 +1,103 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudsql
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"example.com/shared/logging"
+	mexporter "github.com/GoogleCloudPlatform/opentelemetry-operations-go/exporter/metric"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/unit"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// Metric names, exported to Cloud Monitoring as workload.googleapis.com/<NAME>.
+const (
+	// eventsProcessedMetric counts the events processed by the function, with a status attribute of ok or error.
+	eventsProcessedMetric = "cloudsql.events_processed"
+	// queryLatencyMetric is a histogram of the latency of the database query, in milliseconds.
+	queryLatencyMetric = "cloudsql.query_latency"
+)
+
+// meterProvider exports the metrics to Cloud Monitoring, it is nil when metrics are disabled.
+var meterProvider *sdkmetric.MeterProvider
+
+// The instruments are no-op unless ENABLE_METRICS is true.
+var (
+	eventsProcessed instrument.Int64Counter
+	queryLatency    instrument.Float64Histogram
+)
+
+func init() {
+	var provider metric.MeterProvider = metric.NewNoopMeterProvider()
+	if os.Getenv("ENABLE_METRICS") == "true" {
+		exporter, err := mexporter.New()
+		if err != nil {
+			logging.Warning(context.Background(), "Metrics disabled, error creating the Cloud Monitoring exporter: %v", err)
+		} else {
+			meterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter)))
+			provider = meterProvider
+		}
+	}
+
+	if err := createInstruments(provider.Meter("example.com/cloudsql")); err != nil {
+		logging.Warning(context.Background(), "Metrics disabled, error creating the instruments: %v", err)
+		meterProvider = nil
+		createInstruments(metric.NewNoopMeterProvider().Meter("example.com/cloudsql"))
+	}
+}
+
+// createInstruments creates the instruments of the function from the meter.
+func createInstruments(meter metric.Meter) (err error) {
+	eventsProcessed, err = meter.Int64Counter(eventsProcessedMetric, instrument.WithDescription("Events processed by the function"))
+	if err != nil {
+		return err
+	}
+	queryLatency, err = meter.Float64Histogram(queryLatencyMetric, instrument.WithDescription("Latency of the database query"), instrument.WithUnit(unit.Milliseconds))
+	return err
+}
+
+// recordEvent counts a processed event, with its status.
+func recordEvent(err error) {
+	eventsProcessed.Add(context.Background(), 1, statusAttribute(err))
+}
+
+// recordQueryLatency records the latency of a database query, with its status.
+func recordQueryLatency(latency time.Duration, err error) {
+	queryLatency.Record(context.Background(), float64(latency)/float64(time.Millisecond), statusAttribute(err))
+}
+
+func statusAttribute(err error) attribute.KeyValue {
+	if err != nil {
+		return attribute.String("status", "error")
+	}
+	return attribute.String("status", "ok")
+}
+
+// flushMetrics exports the recorded metrics before the function returns, since the instance CPU can be throttled right after
+// and the periodic export of the reader may never run for short-lived instances.
+func flushMetrics() {
+	if meterProvider == nil {
+		return
+	}
+	if err := meterProvider.ForceFlush(context.Background()); err != nil {
+		logging.Error(context.Background(), "Error exporting metrics: %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudsql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"example.com/shared/logging"
+	"github.com/go-sql-driver/mysql"
+)
+
+// erAccessDenied is the number of the MySQL error returned when the login of the user fails.
+const erAccessDenied = 1045
+
+// The password of the secret version of SECRET_NAME, cached by the instance of the function.
+var (
+	passwordMu     sync.Mutex
+	cachedPassword string
+	secretClient   *secretmanager.Client
+)
+
+// secretConnector opens the connections of the pool with the password stored in a Secret Manager secret version,
+// so the password is never set in the environment of the function. It is read once and cached, and read again when
+// the database rejects it, like after a rotation of the password, before retrying the connection.
+type secretConnector struct {
+	// cfg is the configuration parsed from the DSN, without the password.
+	cfg *mysql.Config
+	// secretVersion is the resource name of the secret version, projects/<PROJECT>/secrets/<SECRET>/versions/<VERSION>.
+	secretVersion string
+}
+
+// Connect implements driver.Connector.
+func (c *secretConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.connect(ctx, false)
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == erAccessDenied {
+		logging.Warning(ctx, "Access denied, reading the password secret again.")
+		return c.connect(ctx, true)
+	}
+	return conn, err
+}
+
+// Driver implements driver.Connector.
+func (c *secretConnector) Driver() driver.Driver {
+	return &mysql.MySQLDriver{}
+}
+
+func (c *secretConnector) connect(ctx context.Context, refresh bool) (driver.Conn, error) {
+	password, err := secretPassword(ctx, c.secretVersion, refresh)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := c.cfg.Clone()
+	cfg.Passwd = password
+	connector, err := mysql.NewConnector(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error creating the MySQL connector: %w", err)
+	}
+	return connector.Connect(ctx)
+}
+
+// secretPassword returns the cached password, reading the secret version when it isn't cached yet or refresh is set.
+func secretPassword(ctx context.Context, secretVersion string, refresh bool) (string, error) {
+	passwordMu.Lock()
+	defer passwordMu.Unlock()
+
+	if cachedPassword != "" && !refresh {
+		return cachedPassword, nil
+	}
+
+	if secretClient == nil {
+		client, err := secretmanager.NewClient(context.Background())
+		if err != nil {
+			return "", fmt.Errorf("error creating the Secret Manager client: %w", err)
+		}
+		secretClient = client
+	}
+
+	logging.Info(ctx, "Read password secret.")
+	res, err := secretClient.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{Name: secretVersion})
+	if err != nil {
+		return "", fmt.Errorf("error reading secret version %s: %w", secretVersion, err)
+	}
+	cachedPassword = string(res.Payload.Data)
+	return cachedPassword, nil
+}
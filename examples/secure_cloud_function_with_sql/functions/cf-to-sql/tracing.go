@@ -0,0 +1,69 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudsql
+
+import (
+	"context"
+	"os"
+
+	"example.com/shared/logging"
+	texporter "github.com/GoogleCloudPlatform/opentelemetry-operations-go/exporter/trace"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer creates the spans of the function. It is a no-op tracer unless ENABLE_TRACING is true.
+var tracer = otel.Tracer("example.com/cloudsql")
+
+// tracerProvider exports the spans to Cloud Trace, it is nil when tracing is disabled.
+var tracerProvider *sdktrace.TracerProvider
+
+func init() {
+	if os.Getenv("ENABLE_TRACING") != "true" {
+		return
+	}
+
+	exporter, err := texporter.New()
+	if err != nil {
+		logging.Warning(context.Background(), "Tracing disabled, error creating the Cloud Trace exporter: %v", err)
+		return
+	}
+	tracerProvider = sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	tracer = tracerProvider.Tracer("example.com/cloudsql")
+}
+
+// endSpan records the error, if any, and ends the span.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// flushSpans exports the pending spans before the function returns, since the instance CPU can be throttled right after.
+func flushSpans() {
+	if tracerProvider == nil {
+		return
+	}
+	if err := tracerProvider.ForceFlush(context.Background()); err != nil {
+		logging.Error(context.Background(), "Error exporting spans: %v", err)
+	}
+}
@@ -0,0 +1,17 @@
+module example.com/shared
+
+go 1.18
+
+require (
+	cloud.google.com/go/compute/metadata v0.2.3
+	github.com/cloudevents/sdk-go/v2 v2.14.0
+	go.opentelemetry.io/otel v1.14.0
+	go.opentelemetry.io/otel/trace v1.14.0
+)
+
+require (
+	cloud.google.com/go/compute v1.14.0 // indirect
+	github.com/json-iterator/go v1.1.10 // indirect
+	github.com/modern-go/concurrent v0.0.0-20180228061459-e0a39a4cb421 // indirect
+	github.com/modern-go/reflect2 v0.0.0-20180701023420-4b7aa43c6742 // indirect
+)
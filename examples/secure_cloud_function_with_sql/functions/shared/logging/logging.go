@@ -0,0 +1,121 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logging writes the structured log entries of the example functions. Each entry is a JSON line on stdout,
+// which the logging agent of Cloud Functions parses into a log entry with its severity, labels and trace, see
+// https://cloud.google.com/logging/docs/structured-logging#special-payload-fields.
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"cloud.google.com/go/compute/metadata"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Severities of the log entries, see https://cloud.google.com/logging/docs/reference/v2/rest/v2/LogEntry#LogSeverity.
+const (
+	severityInfo    = "INFO"
+	severityWarning = "WARNING"
+	severityError   = "ERROR"
+)
+
+type entry struct {
+	Severity     string            `json:"severity"`
+	Message      string            `json:"message"`
+	Trace        string            `json:"logging.googleapis.com/trace,omitempty"`
+	SpanID       string            `json:"logging.googleapis.com/spanId,omitempty"`
+	TraceSampled bool              `json:"logging.googleapis.com/trace_sampled,omitempty"`
+	Labels       map[string]string `json:"logging.googleapis.com/labels,omitempty"`
+}
+
+type labelsKey struct{}
+
+var (
+	projectIDOnce sync.Once
+	projectID     string
+)
+
+// ForEvent returns a context whose log entries are labeled with the ID, type and source of the event, and carry the
+// trace of its CloudEvents distributed tracing extension. The W3C trace context is extracted even when the function
+// doesn't export spans, so the entries of an invocation are still listed with the trace of the event.
+func ForEvent(ctx context.Context, e event.Event) context.Context {
+	ctx = context.WithValue(ctx, labelsKey{}, map[string]string{
+		"event_id":     e.ID(),
+		"event_type":   e.Type(),
+		"event_source": e.Source(),
+	})
+
+	carrier := propagation.MapCarrier{}
+	for _, key := range []string{"traceparent", "tracestate"} {
+		if value, ok := e.Extensions()[key]; ok {
+			carrier.Set(key, fmt.Sprint(value))
+		}
+	}
+	return propagation.TraceContext{}.Extract(ctx, carrier)
+}
+
+// Info writes an entry with the INFO severity.
+func Info(ctx context.Context, format string, args ...interface{}) {
+	write(ctx, severityInfo, format, args...)
+}
+
+// Warning writes an entry with the WARNING severity.
+func Warning(ctx context.Context, format string, args ...interface{}) {
+	write(ctx, severityWarning, format, args...)
+}
+
+// Error writes an entry with the ERROR severity.
+func Error(ctx context.Context, format string, args ...interface{}) {
+	write(ctx, severityError, format, args...)
+}
+
+func write(ctx context.Context, severity string, format string, args ...interface{}) {
+	e := entry{
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	}
+	if labels, ok := ctx.Value(labelsKey{}).(map[string]string); ok {
+		e.Labels = labels
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		if project := traceProjectID(); project != "" {
+			e.Trace = fmt.Sprintf("projects/%s/traces/%s", project, sc.TraceID())
+			e.SpanID = sc.SpanID().String()
+			e.TraceSampled = sc.IsSampled()
+		}
+	}
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		line = []byte(fmt.Sprintf(`{"severity":%q,"message":%q}`, severityError, "error encoding log entry: "+err.Error()))
+	}
+	fmt.Fprintln(os.Stdout, string(line))
+}
+
+// traceProjectID returns the ID of the project of the function, which the trace of the log entries is named after.
+func traceProjectID() string {
+	projectIDOnce.Do(func() {
+		if metadata.OnGCE() {
+			projectID, _ = metadata.ProjectID()
+		}
+	})
+	return projectID
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package retry classifies the errors of the example functions. The event trigger retries the events of the failed
+// invocations, so a function returns the transient errors only, and acknowledges the events failing with an error which
+// would fail again on every retry.
+package retry
+
+import (
+	"context"
+	"errors"
+	"net"
+	"syscall"
+)
+
+// Classifier reports whether err is a permanent error of a database, ok being false for the errors it doesn't know.
+type Classifier func(err error) (permanent, ok bool)
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks err as an error which fails again when the event is retried, like an invalid configuration.
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// IsTransient reports whether err can succeed when the event is retried, like when the database is unreachable or
+// restarting, or the invocation ran out of time. The errors marked by Permanent and the database errors classified as
+// permanent by one of the classifiers are not. Other errors are transient, so an unexpected failure is retried rather
+// than dropping the event.
+func IsTransient(err error, classifiers ...Classifier) bool {
+	var permanentErr *permanentError
+	if errors.As(err, &permanentErr) {
+		return false
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	for _, classify := range classifiers {
+		if permanent, ok := classify(err); ok {
+			return !permanent
+		}
+	}
+	return true
+}
+
+// Classes of the PostgreSQL SQLSTATE codes which fail again when the event is retried, see
+// https://www.postgresql.org/docs/current/errcodes-appendix.html.
+var permanentSQLStateClasses = map[string]bool{
+	"28": true, // Invalid Authorization Specification, like a rejected password
+	"3D": true, // Invalid Catalog Name, like a missing database
+	"3F": true, // Invalid Schema Name
+	"42": true, // Syntax Error or Access Rule Violation, like a missing table or column
+}
+
+// PostgreSQL classifies the errors reported by a PostgreSQL server by their SQLSTATE class. It matches the errors with
+// a SQLState method, like the *pgconn.PgError of pgx, so the module doesn't depend on a driver.
+func PostgreSQL(err error) (permanent, ok bool) {
+	var stateErr interface{ SQLState() string }
+	if !errors.As(err, &stateErr) || len(stateErr.SQLState()) != 5 {
+		return false, false
+	}
+	return permanentSQLStateClasses[stateErr.SQLState()[:2]], true
+}
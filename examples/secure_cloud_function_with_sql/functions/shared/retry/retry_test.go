@@ -0,0 +1,59 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+)
+
+type sqlStateError string
+
+func (e sqlStateError) Error() string    { return "SQLSTATE " + string(e) }
+func (e sqlStateError) SQLState() string { return string(e) }
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"deadline exceeded", fmt.Errorf("error during ping: %w", context.DeadlineExceeded), true},
+		{"connection refused", &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, true},
+		{"connection reset", fmt.Errorf("error reading row: %w", syscall.ECONNRESET), true},
+		{"network timeout", &net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded}, true},
+		{"unknown error", errors.New("unexpected"), true},
+		{"permanent", Permanent(errors.New("invalid DB_CONNECT_TIMEOUT_SECONDS")), false},
+		{"wrapped permanent timeout", fmt.Errorf("error during ping: %w", Permanent(context.DeadlineExceeded)), false},
+		{"too many connections", sqlStateError("53300"), true},
+		{"serialization failure", fmt.Errorf("error selecting from table: %w", sqlStateError("40001")), true},
+		{"missing table", fmt.Errorf("error selecting from table: %w", sqlStateError("42P01")), false},
+		{"rejected password", sqlStateError("28P01"), false},
+		{"missing database", sqlStateError("3D000"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTransient(tt.err, PostgreSQL); got != tt.want {
+				t.Errorf("IsTransient(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -26,6 +26,17 @@ locals {
   secret_name     = "sct-sql-password"
   labels          = { "env" = "dev" }
   subnet_ip       = "10.0.0.0/28"
+
+  source_dir = "${path.module}/functions/cf-to-sql"
+  shared_dir = "${path.module}/functions/shared"
+
+  // The archive vendors the shared module of the functions under shared/, so the replace directive of the function
+  // go.mod is pointed to the copy instead of the sibling directory
+  source_files = merge(
+    { for f in fileset(local.shared_dir, "**") : "shared/${f}" => file("${local.shared_dir}/${f}") },
+    { for f in fileset(local.source_dir, "**") : f => f == "go.mod" ? replace(file("${local.source_dir}/${f}"), "=> ../shared", "=> ./shared") : file("${local.source_dir}/${f}") if length(regexall("\\.zip$", f)) == 0 }
+  )
+  source_hash = sha256(join(",", [for f in sort(keys(local.source_files)) : "${f}:${sha256(local.source_files[f])}"]))
 }
 
 resource "random_id" "random_folder_suffix" {
@@ -394,17 +405,24 @@ resource "null_resource" "create_and_populate_db" {
 
 data "archive_file" "cf_cloudsql_source" {
   type        = "zip"
-  source_dir  = "${path.module}/functions/cf-to-sql/"
   output_path = "functions/cloudfunction-sql-source-${random_id.random_folder_suffix.hex}.zip"
+
+  dynamic "source" {
+    for_each = local.source_files
+    content {
+      filename = source.key
+      content  = source.value
+    }
+  }
 }
 
 resource "google_storage_bucket_object" "cf_cloudsql_source_zip" {
   source       = data.archive_file.cf_cloudsql_source.output_path
   content_type = "application/zip"
 
-  # Append to the MD5 checksum of the files's content
+  # Append to the checksum of the files's content
   # to force the zip to be updated as soon as a change occurs
-  name   = "src-${data.archive_file.cf_cloudsql_source.output_md5}.zip"
+  name   = "src-${local.source_hash}.zip"
   bucket = module.cloudfunction_source_bucket.name
 
   depends_on = [
@@ -516,14 +534,20 @@ module "secure_cloud_function" {
     INSTANCE_LOCATION   = local.region
     INSTANCE_NAME       = module.safer_mysql_db.instance_name
     DATABASE_NAME       = local.db_name
-  }
 
-  secret_environment_variables = [{
-    key_name   = "INSTANCE_PWD"
-    project_id = module.secure_harness.security_project_id
-    secret     = local.secret_name
-    version    = data.google_secret_manager_secret_version.latest_version.version
-  }]
+    INSTANCE_CONNECTION_NAME = module.safer_mysql_db.instance_connection_name
+
+    DB_CONNECT_TIMEOUT_SECONDS = "10"
+    DB_CONNECT_MAX_RETRIES     = "3"
+
+    DB_MAX_OPEN_CONNS            = "5"
+    DB_MAX_IDLE_CONNS            = "2"
+    DB_CONN_MAX_LIFETIME_SECONDS = "1800"
+
+    # The function reads the password from the latest version of the secret, so it's not set in its environment, and
+    # reads it again when the database rejects it after a rotation
+    SECRET_NAME = "${google_secret_manager_secret.password_secret.id}/versions/latest"
+  }
 
   event_trigger = {
     trigger_region        = local.location
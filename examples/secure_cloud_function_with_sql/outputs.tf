@@ -122,6 +122,11 @@ output "mysql_public_ip_address" {
 output "mysql_private_ip_address" {
   description = "The first private (PRIVATE) IPv4 address assigned for the master instance."
   value       = module.safer_mysql_db.private_ip_address
+
+  precondition {
+    condition     = coalesce(module.safer_mysql_db.public_ip_address, "none") == "none" && coalesce(module.safer_mysql_db.private_ip_address, "none") != "none"
+    error_message = "The Cloud SQL instance must only have a private IP, in the private services access range of the Shared VPC."
+  }
 }
 
 output "mysql_user" {
@@ -163,3 +168,8 @@ output "topic_id" {
   value       = module.pubsub.id
   description = "The Pub/Sub topic which will trigger Cloud Function."
 }
+
+output "source_hash" {
+  value       = local.source_hash
+  description = "The checksum of the Cloud Function source files, used to name the source object."
+}
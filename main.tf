@@ -14,12 +14,389 @@
  * limitations under the License.
  */
 
+locals {
+  function_name = "${var.name_prefix}${var.function_name}${var.name_suffix}"
+
+  // The sensitive mark follows the URI through the interpolations, so the outputs built from it are redacted too
+  function_uri = var.mark_uri_sensitive ? sensitive(google_cloudfunctions2_function.function.service_config[0].uri) : google_cloudfunctions2_function.function.service_config[0].uri
+
+  buildpack_env_variables = {
+    for key, value in {
+      GOOGLE_RUNTIME_VERSION = var.buildpack_config.runtime_version
+      GOOGLE_BUILDABLE       = var.buildpack_config.buildable
+      GOOGLE_GOGCFLAGS       = var.buildpack_config.go_gcflags
+      GOOGLE_GOLDFLAGS       = var.buildpack_config.go_ldflags
+      GOOGLE_CLEAR_SOURCE    = var.buildpack_config.clear_source == null ? null : tostring(var.buildpack_config.clear_source)
+      // Derived from the module inputs, so the build matches the function registered by the functions framework
+      GOOGLE_FUNCTION_TARGET         = coalesce(var.buildpack_config.function_target, var.entrypoint)
+      GOOGLE_FUNCTION_SIGNATURE_TYPE = coalesce(var.buildpack_config.function_signature_type, var.event_trigger == null ? "http" : "cloudevent")
+    } : key => value if value != null
+  }
+
+  // Label values are limited to 63 lowercase letters, numbers, underscores and hyphens
+  deployment_labels = { for key, value in var.deployment_metadata : key => substr(replace(lower(value), "/[^a-z0-9_-]/", "-"), 0, 63) }
+
+  // Archives from an URL and inline sources are uploaded as an object named after their checksum, so a change redeploys the function
+  storage_source = var.url_source != null ? {
+    bucket     = var.url_source.bucket
+    object     = "src-${null_resource.url_source_upload[0].triggers.sha256}.zip"
+    generation = null
+    } : var.inline_source != null ? {
+    bucket     = google_storage_bucket_object.inline_source[0].bucket
+    object     = google_storage_bucket_object.inline_source[0].name
+    generation = null
+  } : var.storage_source
+
+  inline_source_files = var.inline_source == null ? {} : merge(
+    can(regex("^go", var.runtime)) ? { "go.mod" = "module example.com/${replace(lower(local.function_name), "/[^a-z0-9-]/", "-")}\n\ngo 1.18\n\nrequire github.com/GoogleCloudPlatform/functions-framework-go v1.7.1\n" } : {},
+    var.inline_source.files
+  )
+  // File expected to register the entrypoint for each runtime language
+  inline_source_entrypoint_file = can(regex("^go", var.runtime)) ? length([for name in keys(local.inline_source_files) : name if can(regex("\\.go$", name))]) > 0 : can(regex("^python", var.runtime)) ? contains(keys(local.inline_source_files), "main.py") : can(regex("^nodejs", var.runtime)) ? contains(keys(local.inline_source_files), "index.js") || contains(keys(local.inline_source_files), "package.json") : true
+
+  metadata_env_variables = var.inject_metadata_env ? {
+    GCP_PROJECT     = var.project_id
+    FUNCTION_REGION = var.function_location
+    FUNCTION_NAME   = local.function_name
+  } : {}
+  runtime_env_variables = merge(concat([local.metadata_env_variables], var.environment_variables_layers, [
+    { for k, v in var.environment_variables_any : k => tostring(v) },
+    try(var.service_config.runtime_env_variables, null) == null ? {} : var.service_config.runtime_env_variables
+  ])...)
+  reserved_env_variables = [for key in keys(local.runtime_env_variables) : key if contains(["PORT", "K_SERVICE", "K_REVISION", "K_CONFIGURATION", "FUNCTION_TARGET", "FUNCTION_SIGNATURE_TYPE"], key) || can(regex("^X_GOOGLE_", key))]
+
+  // The names and values of the runtime environment variables are limited to 32 KiB in total
+  runtime_env_variables_sizes = { for key, value in local.runtime_env_variables : key => length(key) + length(value) }
+  runtime_env_variables_size  = sum(concat([0], values(local.runtime_env_variables_sizes)))
+
+  // Storage triggers, direct or through Cloud Audit Logs, don't fire until the service agents and the trigger identity are granted their roles
+  storage_trigger           = can(regex("^google\\.cloud\\.storage\\.", try(var.event_trigger.event_type, "")))
+  storage_audit_log_trigger = try(var.event_trigger.event_type == "google.cloud.audit.log.v1.written" && contains([for filter in(var.event_trigger.event_filters == null ? [] : var.event_trigger.event_filters) : filter.attribute_value if filter.attribute == "serviceName"], "storage.googleapis.com"), false)
+  manage_storage_iam        = var.manage_eventarc_iam && (local.storage_trigger || local.storage_audit_log_trigger)
+
+  // Changes to any of these inputs deploy a new revision of the function
+  deploy_inputs_hash = sha256(jsonencode({
+    runtime        = var.runtime
+    entrypoint     = var.entrypoint
+    storage        = local.storage_source
+    repo           = var.repo_source
+    build_env      = var.build_env_variables
+    service_config = local.service_config
+    runtime_env    = local.runtime_env_variables
+  }))
+
+  // Builds in a worker pool of another project need the pool project to grant access to the function project identities.
+  // The pool project is an input, since the worker_pool can be the ID of a pool created in the same apply
+  cross_project_building = var.worker_pool_project_id != null && var.worker_pool_project_id != var.project_id
+
+  // Eventarc triggers with a user provided transport topic can't be managed by the Cloud Functions API
+  use_custom_transport = var.event_trigger != null ? try(var.event_trigger.transport_topic != null, false) : false
+
+  resource_profiles = {
+    small  = { available_memory = "256M", available_cpu = "0.1666", max_instance_request_concurrency = "1", timeout_seconds = "60", max_instance_count = "100" }
+    medium = { available_memory = "1Gi", available_cpu = "1", max_instance_request_concurrency = "10", timeout_seconds = "300", max_instance_count = "50" }
+    large  = { available_memory = "4Gi", available_cpu = "2", max_instance_request_concurrency = "80", timeout_seconds = "540", max_instance_count = "20" }
+  }
+  resource_profile = var.resource_profile == null ? local.resource_profiles["small"] : local.resource_profiles[var.resource_profile]
+
+  // Fields explicitly set in the service_config take precedence over the resource profile. Without a profile, the CPU and
+  // the concurrency are left to the platform, which derives them from the memory
+  service_config = var.service_config == null ? null : merge(var.service_config, {
+    max_instance_count               = coalesce(var.service_config.max_instance_count, local.resource_profile.max_instance_count)
+    min_instance_count               = coalesce(var.service_config.min_instance_count, "1")
+    available_memory                 = coalesce(var.service_config.available_memory, local.resource_profile.available_memory)
+    available_cpu                    = var.resource_profile == null ? var.service_config.available_cpu : coalesce(var.service_config.available_cpu, local.resource_profile.available_cpu)
+    max_instance_request_concurrency = var.resource_profile == null ? var.service_config.max_instance_request_concurrency : coalesce(var.service_config.max_instance_request_concurrency, local.resource_profile.max_instance_request_concurrency)
+    timeout_seconds                  = coalesce(var.service_config.timeout_seconds, local.resource_profile.timeout_seconds)
+  })
+
+  // Event triggers reach functions with internal ingress only when the trigger identity can invoke the Cloud Run service
+  internal_ingress = contains(["ALLOW_INTERNAL_ONLY", "ALLOW_INTERNAL_AND_GCLB"], try(var.service_config.ingress_settings, ""))
+
+  cloud_run_service_name = element(split("/", google_cloudfunctions2_function.function.service_config[0].service), 5)
+
+  size_units = { "M" = 1000000, "Mi" = 1048576, "G" = 1000000000, "Gi" = 1073741824 }
+  memory_bytes = try(
+    tonumber(regex("^([0-9]+)", local.service_config.available_memory)[0]) * local.size_units[regex("(M|Mi|G|Gi)$", local.service_config.available_memory)[0]],
+    null
+  )
+  tmp_volume_bytes = try(
+    tonumber(regex("^([0-9]+)", var.tmp_volume_size_limit)[0]) * local.size_units[regex("(M|Mi|G|Gi)$", var.tmp_volume_size_limit)[0]],
+    null
+  )
+
+  // The container_startup_timeout_seconds is turned into a failure threshold of the startup probe, probing every 10 seconds by default
+  startup_probe_period            = coalesce(try(var.startup_probe.period_seconds, null), 10)
+  startup_probe_initial_delay     = coalesce(try(var.startup_probe.initial_delay_seconds, null), 0)
+  startup_probe_period_value      = var.container_startup_timeout_seconds != null ? local.startup_probe_period : try(var.startup_probe.period_seconds, null)
+  startup_probe_failure_threshold = var.container_startup_timeout_seconds != null ? max(1, ceil((var.container_startup_timeout_seconds - local.startup_probe_initial_delay) / local.startup_probe_period)) : try(var.startup_probe.failure_threshold, null)
+
+  startup_probe = var.startup_probe == null && var.container_startup_timeout_seconds == null ? [] : compact([
+    "tcpSocket.port=${var.container_port == null ? 8080 : var.container_port}",
+    try(var.startup_probe.initial_delay_seconds, null) != null ? "initialDelaySeconds=${var.startup_probe.initial_delay_seconds}" : "",
+    try(var.startup_probe.timeout_seconds, null) != null ? "timeoutSeconds=${var.startup_probe.timeout_seconds}" : "",
+    local.startup_probe_period_value != null ? "periodSeconds=${local.startup_probe_period_value}" : "",
+    local.startup_probe_failure_threshold != null ? "failureThreshold=${local.startup_probe_failure_threshold}" : "",
+  ])
+
+  liveness_probe = var.health_check_path == null ? [] : compact([
+    "httpGet.path=${var.health_check_path}",
+    "httpGet.port=${var.container_port == null ? 8080 : var.container_port}",
+    try(var.liveness_probe.initial_delay_seconds, null) != null ? "initialDelaySeconds=${var.liveness_probe.initial_delay_seconds}" : "",
+    try(var.liveness_probe.timeout_seconds, null) != null ? "timeoutSeconds=${var.liveness_probe.timeout_seconds}" : "",
+    try(var.liveness_probe.period_seconds, null) != null ? "periodSeconds=${var.liveness_probe.period_seconds}" : "",
+    try(var.liveness_probe.failure_threshold, null) != null ? "failureThreshold=${var.liveness_probe.failure_threshold}" : "",
+  ])
+
+  // Settings of the backing Cloud Run service which are not exposed by the Cloud Functions API
+  cloud_run_service_flags = compact(flatten([
+    var.execution_environment != null ? "--execution-environment=${lower(trimprefix(var.execution_environment, "EXECUTION_ENVIRONMENT_"))}" : "",
+    [for i, nfs in var.nfs_volumes : [
+      "--add-volume=name=nfs-${i},type=nfs,location=${nfs.server}:${nfs.path},readonly=${nfs.read_only}",
+      "--add-volume-mount=volume=nfs-${i},mount-path=${nfs.mount_path}"
+    ]],
+    var.tmp_volume_size_limit != null ? [
+      "--add-volume=name=tmp,type=in-memory,size-limit=${var.tmp_volume_size_limit}",
+      "--add-volume-mount=volume=tmp,mount-path=/tmp"
+    ] : [],
+    length(local.startup_probe) > 0 ? "--startup-probe=${join(",", local.startup_probe)}" : "",
+    length(local.liveness_probe) > 0 ? "--liveness-probe=${join(",", local.liveness_probe)}" : "",
+    var.container_port != null ? "--port=${var.container_port}" : "",
+    length(var.revision_labels) > 0 ? "--update-labels=${join(",", [for key, value in var.revision_labels : "${key}=${value}"])}" : "",
+    var.cpu_always_allocated ? "--no-cpu-throttling" : "",
+    var.streaming_timeout_seconds != null ? "--timeout=${var.streaming_timeout_seconds}s" : "",
+    length(var.cloudsql_instances) > 0 ? "--set-cloudsql-instances=${join(",", var.cloudsql_instances)}" : "",
+    var.binary_authorization_breakglass_justification != null ? "'--breakglass=${replace(var.binary_authorization_breakglass_justification, "'", "'\\''")}'" : "",
+  ]))
+
+  required_apis = sort(compact([
+    "cloudfunctions.googleapis.com",
+    "run.googleapis.com",
+    "cloudbuild.googleapis.com",
+    "artifactregistry.googleapis.com",
+    var.repo_source == null ? "storage-api.googleapis.com" : "",
+    var.repo_source != null ? "sourcerepo.googleapis.com" : "",
+    var.event_trigger != null ? "eventarc.googleapis.com" : "",
+    var.event_trigger != null ? "pubsub.googleapis.com" : "",
+    can(regex("^google\\.cloud\\.(firestore|datastore)\\.", try(var.event_trigger.event_type, ""))) ? "firestore.googleapis.com" : "",
+    try(var.service_config.runtime_secret_env_variables != null || var.service_config.secret_volumes != null, false) ? "secretmanager.googleapis.com" : "",
+    try(var.service_config.vpc_connector != null, false) ? "vpcaccess.googleapis.com" : "",
+    length(var.cloudsql_instances) > 0 ? "sqladmin.googleapis.com" : "",
+  ]))
+}
+
+/******************************************
+	Source from an URL
+ *****************************************/
+resource "null_resource" "url_source_upload" {
+  count = var.url_source != null ? 1 : 0
+
+  triggers = {
+    url    = var.url_source.url
+    sha256 = var.url_source.sha256
+    bucket = var.url_source.bucket
+  }
+
+  provisioner "local-exec" {
+    command = <<EOT
+      set -e
+      archive=$(mktemp)
+      curl -fsSL "${var.url_source.url}" -o $archive
+      echo "${var.url_source.sha256}  $archive" | sha256sum --check --quiet
+      gcloud storage cp $archive gs://${var.url_source.bucket}/src-${var.url_source.sha256}.zip --quiet
+      rm -f $archive
+    EOT
+  }
+}
+
+/******************************************
+	Inline source
+ *****************************************/
+data "archive_file" "inline_source" {
+  count = var.inline_source != null ? 1 : 0
+
+  type        = "zip"
+  output_path = "${path.root}/.terraform/inline-source/${local.function_name}.zip"
+
+  dynamic "source" {
+    for_each = local.inline_source_files
+    content {
+      filename = source.key
+      content  = source.value
+    }
+  }
+}
+
+resource "google_storage_bucket_object" "inline_source" {
+  count = var.inline_source != null ? 1 : 0
+
+  name   = "src-${sha256(jsonencode(local.inline_source_files))}.zip"
+  bucket = var.inline_source.bucket
+  source = data.archive_file.inline_source[0].output_path
+
+  lifecycle {
+    precondition {
+      condition     = local.inline_source_entrypoint_file
+      error_message = "The inline_source files must include the file registering the entrypoint: a .go file for Go, main.py for Python, and index.js or package.json for Node.js runtimes."
+    }
+  }
+}
+
+/******************************************
+	Approval gate
+ *****************************************/
+// Runs before every deployment, failing the apply unless the approval_check_command accepts the approval_token
+resource "null_resource" "approval_gate" {
+  count = var.require_approval_token ? 1 : 0
+
+  triggers = {
+    deploy_inputs  = local.deploy_inputs_hash
+    approval_token = var.approval_token == null ? null : sha256(var.approval_token)
+  }
+
+  provisioner "local-exec" {
+    command = var.approval_check_command
+    environment = {
+      APPROVAL_TOKEN     = var.approval_token
+      FUNCTION_NAME      = local.function_name
+      FUNCTION_PROJECT   = var.project_id
+      FUNCTION_LOCATION  = var.function_location
+      DEPLOY_INPUTS_HASH = local.deploy_inputs_hash
+    }
+  }
+
+  lifecycle {
+    precondition {
+      condition     = var.approval_token != null && var.approval_check_command != null
+      error_message = "The approval_token and the approval_check_command are required when require_approval_token is true."
+    }
+  }
+}
+
+/******************************************
+	Rollback on failure
+ *****************************************/
+// Checks the deployment once the function and its backing service are updated. Cloud Run only sends the traffic to
+// ready revisions, so the previous revision keeps serving, and the check fails the apply to surface the failure
+resource "null_resource" "rollback_on_failure" {
+  count = var.rollback_on_failure ? 1 : 0
+
+  triggers = {
+    deploy_inputs        = local.deploy_inputs_hash
+    function_update_time = google_cloudfunctions2_function.function.update_time
+  }
+
+  provisioner "local-exec" {
+    command = <<EOT
+      for attempt in $(seq 1 180); do
+        state=$(gcloud functions describe ${local.function_name} \
+          --project=${var.project_id} \
+          --region=${var.function_location} \
+          --gen2 \
+          --format="value(state)")
+        [ "$state" != "DEPLOYING" ] && break
+        sleep 10
+      done
+
+      service=${local.cloud_run_service_name}
+      created=$(gcloud run services describe $service \
+        --project=${var.project_id} \
+        --region=${var.function_location} \
+        --format='value(status.latestCreatedRevisionName)')
+      ready=$(gcloud run services describe $service \
+        --project=${var.project_id} \
+        --region=${var.function_location} \
+        --format='value(status.latestReadyRevisionName)')
+
+      if [ "$state" = "ACTIVE" ] && [ "$created" = "$ready" ]; then
+        exit 0
+      fi
+
+      echo "The deployment of ${local.function_name} failed: state $state, latest revision $created, serving the latest ready revision $ready"
+      exit 1
+    EOT
+  }
+
+  depends_on = [
+    google_cloudfunctions2_function.function,
+    null_resource.cloud_run_service_update
+  ]
+}
+
+/******************************************
+	Source retention
+ *****************************************/
+// The copies aren't managed by Terraform, so they are kept when the function and its source are destroyed
+resource "null_resource" "source_retention" {
+  count = var.retain_source_on_destroy ? 1 : 0
+
+  triggers = {
+    source      = local.storage_source == null ? null : "gs://${local.storage_source.bucket}/${local.storage_source.object}${try(local.storage_source.generation, null) != null ? "#${local.storage_source.generation}" : ""}"
+    destination = local.storage_source == null || var.source_retention_bucket == null ? null : "gs://${var.source_retention_bucket}/${local.function_name}/${local.storage_source.object}"
+  }
+
+  provisioner "local-exec" {
+    command = <<EOT
+      gcloud storage cp '${self.triggers.source}' '${self.triggers.destination}' --quiet
+    EOT
+  }
+
+  lifecycle {
+    precondition {
+      condition     = var.source_retention_bucket != null
+      error_message = "The source_retention_bucket is required when retain_source_on_destroy is true."
+    }
+
+    precondition {
+      condition     = local.storage_source != null
+      error_message = "The retain_source_on_destroy requires a storage_source, url_source or inline_source. Sources from a repository are not stored in a bucket."
+    }
+  }
+}
+
+/******************************************
+	Build serialization
+ *****************************************/
+// Runs when any build input changes, before the function is updated and a new build is started
+resource "null_resource" "wait_for_build" {
+  count = var.serialize_builds ? 1 : 0
+
+  triggers = {
+    build_inputs = sha256(jsonencode({
+      runtime     = var.runtime
+      entrypoint  = var.entrypoint
+      environment = merge(local.buildpack_env_variables, var.build_env_variables != null ? var.build_env_variables : {})
+      storage     = local.storage_source
+      repo        = var.repo_source
+    }))
+  }
+
+  provisioner "local-exec" {
+    command = <<EOT
+      for attempt in $(seq 1 180); do
+        state=$(gcloud functions describe ${local.function_name} \
+          --project=${var.project_id} \
+          --region=${var.function_location} \
+          --gen2 \
+          --format='value(state)' 2>/dev/null || true)
+        if [ "$state" != "DEPLOYING" ]; then
+          exit 0
+        fi
+        echo "Waiting for the in-progress deployment of ${local.function_name} to finish"
+        sleep 10
+      done
+      echo "The in-progress deployment of ${local.function_name} didn't finish in 30 minutes" >&2
+      exit 1
+    EOT
+  }
+}
+
 /******************************************
 	Cloud Function Definition with
 	Repo/Storage Build Source and Event Trigger
  *****************************************/
 resource "google_cloudfunctions2_function" "function" {
-  name        = var.function_name
+  name        = local.function_name
   location    = var.function_location
   description = var.description
   project     = var.project_id
@@ -27,11 +404,11 @@ resource "google_cloudfunctions2_function" "function" {
   build_config {
     runtime               = var.runtime
     entry_point           = var.entrypoint
-    environment_variables = var.build_env_variables
+    environment_variables = merge(local.buildpack_env_variables, var.build_env_variables != null ? var.build_env_variables : {})
 
     source {
       dynamic "storage_source" {
-        for_each = var.repo_source == null ? [var.storage_source] : []
+        for_each = var.repo_source == null ? [local.storage_source] : []
         content {
           bucket     = storage_source.value.bucket
           object     = storage_source.value.object
@@ -40,7 +417,7 @@ resource "google_cloudfunctions2_function" "function" {
       }
 
       dynamic "repo_source" {
-        for_each = var.storage_source == null ? [var.repo_source] : []
+        for_each = local.storage_source == null ? [var.repo_source] : []
         content {
           project_id   = repo_source.value.project_id
           repo_name    = repo_source.value.repo_name
@@ -58,7 +435,7 @@ resource "google_cloudfunctions2_function" "function" {
   }
 
   dynamic "event_trigger" {
-    for_each = var.event_trigger != null ? [var.event_trigger] : []
+    for_each = var.event_trigger != null && !local.use_custom_transport ? [var.event_trigger] : []
     content {
       trigger_region        = event_trigger.value["trigger_region"] != null ? event_trigger.value["trigger_region"] : null
       event_type            = event_trigger.value["event_type"] != null ? event_trigger.value["event_type"] : null
@@ -78,13 +455,15 @@ resource "google_cloudfunctions2_function" "function" {
   }
 
   dynamic "service_config" {
-    for_each = var.service_config != null ? [var.service_config] : []
+    for_each = local.service_config != null ? [local.service_config] : []
     content {
-      max_instance_count    = service_config.value.max_instance_count
-      min_instance_count    = service_config.value.min_instance_count
-      available_memory      = service_config.value.available_memory
-      timeout_seconds       = service_config.value.timeout_seconds
-      environment_variables = service_config.value.runtime_env_variables != null ? service_config.value.runtime_env_variables : {}
+      max_instance_count               = service_config.value.max_instance_count
+      min_instance_count               = service_config.value.min_instance_count
+      available_memory                 = service_config.value.available_memory
+      available_cpu                    = service_config.value.available_cpu
+      max_instance_request_concurrency = service_config.value.max_instance_request_concurrency
+      timeout_seconds                  = service_config.value.timeout_seconds
+      environment_variables            = local.runtime_env_variables
 
       vpc_connector                 = service_config.value.vpc_connector
       vpc_connector_egress_settings = service_config.value.vpc_connector != null ? service_config.value.vpc_connector_egress_settings : null
@@ -122,7 +501,119 @@ resource "google_cloudfunctions2_function" "function" {
     }
   }
 
-  labels = var.labels != null ? var.labels : {}
+  labels = merge(var.labels != null ? var.labels : {}, local.deployment_labels)
+
+  lifecycle {
+    precondition {
+      condition     = local.runtime_env_variables_size <= 32768
+      error_message = "The runtime environment variables take ${local.runtime_env_variables_size} characters, over the 32 KiB limit of Cloud Functions: ${join(", ", [for key, size in local.runtime_env_variables_sizes : "${key} (${size})" if size >= 1024])}. Move the large values, like certificates, to Secret Manager and mount them with the service_config secret_volumes."
+    }
+
+    precondition {
+      condition     = var.container_startup_timeout_seconds == null || try(var.startup_probe.failure_threshold, null) == null
+      error_message = "The startup_probe failure_threshold can't be set with container_startup_timeout_seconds, which sets it from the period_seconds."
+    }
+
+    precondition {
+      condition     = var.container_startup_timeout_seconds == null || try(var.container_startup_timeout_seconds > local.startup_probe_initial_delay, true)
+      error_message = "The container_startup_timeout_seconds must be greater than the startup_probe initial_delay_seconds."
+    }
+
+    precondition {
+      condition     = length(local.reserved_env_variables) == 0
+      error_message = "The runtime environment variables ${join(", ", local.reserved_env_variables)} are reserved by Cloud Functions and Cloud Run and can't be set."
+    }
+
+    precondition {
+      condition     = length(var.traffic_split) == 0 || !try(local.service_config.all_traffic_on_latest_revision, true)
+      error_message = "The service_config all_traffic_on_latest_revision must be false when traffic_split is set, otherwise every deploy sends all the traffic to the latest revision."
+    }
+
+    precondition {
+      condition     = !var.cpu_always_allocated || try(tonumber(local.service_config.min_instance_count) >= 1, false)
+      error_message = "The service_config min_instance_count must be at least 1 when cpu_always_allocated is true, otherwise the instances scale to zero and stop the work started after the response."
+    }
+
+    precondition {
+      condition     = !var.post_response_work || (var.cpu_always_allocated && try(tonumber(local.service_config.min_instance_count) >= 1, false))
+      error_message = "The post_response_work requires cpu_always_allocated to be true and the service_config min_instance_count to be at least 1, otherwise the work started after the response is throttled or lost on scale down."
+    }
+
+    precondition {
+      condition     = !var.keep_warm || try(tonumber(local.service_config.min_instance_count) >= 1, false)
+      error_message = "The service_config min_instance_count must be at least 1 when keep_warm is true."
+    }
+
+    precondition {
+      condition     = var.event_trigger == null || !local.internal_ingress || try(var.event_trigger.service_account_email != null, false)
+      error_message = "The event_trigger service_account_email is required when the ingress_settings is ALLOW_INTERNAL_ONLY or ALLOW_INTERNAL_AND_GCLB, so the trigger can be granted the Cloud Run Invoker role."
+    }
+
+    precondition {
+      condition     = !var.require_authentication || length(setintersection(["allUsers", "allAuthenticatedUsers"], lookup(var.members, "invokers", []))) == 0
+      error_message = "The invokers members can't include allUsers or allAuthenticatedUsers when require_authentication is true. Set require_authentication to false to make the function public."
+    }
+
+    precondition {
+      condition     = try(var.service_config.ingress_settings, null) != "ALLOW_INTERNAL_ONLY" || length(setintersection(["allUsers", "allAuthenticatedUsers"], lookup(var.members, "invokers", []))) == 0
+      error_message = "The invokers members can't include allUsers or allAuthenticatedUsers when the ingress_settings is ALLOW_INTERNAL_ONLY, since the function is unreachable from outside the network. Use ALLOW_ALL or ALLOW_INTERNAL_AND_GCLB ingress for public functions."
+    }
+
+    precondition {
+      condition     = var.worker_pool_project_id == null || try(element(split("/", var.worker_pool), 1) == var.worker_pool_project_id, false)
+      error_message = "The worker_pool_project_id requires a worker_pool in that project."
+    }
+
+    precondition {
+      condition     = length(compact([for source in [var.url_source, var.inline_source, var.storage_source, var.repo_source] : source == null ? "" : "set"])) <= 1
+      error_message = "Only one of url_source, inline_source, storage_source or repo_source can be set."
+    }
+
+    precondition {
+      condition     = local.tmp_volume_bytes == null || local.memory_bytes == null || try(local.tmp_volume_bytes < local.memory_bytes, true)
+      error_message = "The tmp_volume_size_limit must be lower than the service_config available_memory, since the volume is stored in the instance memory."
+    }
+
+    precondition {
+      condition     = !can(regex("^google\\.cloud\\.(firestore|datastore)\\.", try(var.event_trigger.event_type, ""))) || contains([for filter in try(var.event_trigger.event_filters, []) : filter.attribute], "database")
+      error_message = "Firestore event triggers require a database event filter, like { attribute = \"database\", attribute_value = \"(default)\" }."
+    }
+
+    precondition {
+      condition     = length(local.function_name) <= 63
+      error_message = "The function name, composed by the name_prefix, the function_name and the name_suffix, must have at most 63 characters."
+    }
+
+    precondition {
+      condition     = local.service_config == null ? true : try(tonumber(local.service_config.max_instance_request_concurrency) <= 1 || tonumber(local.service_config.available_cpu) >= 1, true)
+      error_message = "The service_config max_instance_request_concurrency over 1 requires an available_cpu of at least 1."
+    }
+
+    precondition {
+      condition     = length(var.nfs_volumes) == 0 || var.execution_environment == "EXECUTION_ENVIRONMENT_GEN2"
+      error_message = "NFS volumes require the execution_environment to be EXECUTION_ENVIRONMENT_GEN2."
+    }
+
+    precondition {
+      condition     = var.revision_suffix == null ? true : length("${local.function_name}-${var.revision_suffix}-xxxxxx") <= 63
+      error_message = "The revision name, composed by the function_name, the revision_suffix and a 6 characters deploy hash, must have at most 63 characters."
+    }
+
+    precondition {
+      condition     = var.streaming_timeout_seconds == null || var.event_trigger == null
+      error_message = "The streaming_timeout_seconds is only supported by HTTP functions. Event-driven functions are limited to 540 seconds by the timeout_seconds of the service_config."
+    }
+  }
+
+  depends_on = [
+    google_project_iam_member.worker_pool_user,
+    google_project_iam_member.storage_agent_publisher,
+    google_project_iam_member.eventarc_agent,
+    google_project_iam_member.trigger_event_receiver,
+    null_resource.wait_for_build,
+    null_resource.source_retention,
+    null_resource.approval_gate
+  ]
 }
 
 // IAM for invoking HTTP functions (roles/cloudfunctions.invoker)
@@ -139,6 +630,17 @@ resource "google_cloudfunctions2_function_iam_member" "invokers" {
   ]
 }
 
+// Workload Identity Federation callers call the backing Cloud Run service URL directly, which requires roles/run.invoker
+resource "google_cloud_run_service_iam_member" "federated_invokers" {
+  for_each = toset([for member in lookup(var.members, "invokers", []) : member if can(regex("^principal(Set)?://", member))])
+
+  location = google_cloudfunctions2_function.function.location
+  project  = google_cloudfunctions2_function.function.project
+  service  = local.cloud_run_service_name
+  role     = "roles/run.invoker"
+  member   = each.value
+}
+
 // Read and write access to all functions-related resources (roles/cloudfunctions.developer)
 resource "google_cloudfunctions2_function_iam_member" "developers" {
   for_each       = toset(contains(keys(var.members), "developers") ? var.members["developers"] : [])
@@ -152,3 +654,270 @@ resource "google_cloudfunctions2_function_iam_member" "developers" {
     google_cloudfunctions2_function.function
   ]
 }
+
+// The backing Cloud Run service holds the image built from the function source
+// Read after the backing Cloud Run service settings are applied, since they deploy another revision
+data "google_cloud_run_service" "function_service" {
+  name     = local.cloud_run_service_name
+  location = var.function_location
+  project  = var.project_id
+
+  depends_on = [
+    google_cloudfunctions2_function.function,
+    null_resource.cloud_run_service_update
+  ]
+}
+
+// Records the revision built from the source object in its custom metadata, for tracing archived sources
+resource "null_resource" "tag_source_revision" {
+  count = var.tag_source_with_revision ? 1 : 0
+
+  triggers = {
+    source   = local.storage_source == null ? null : "gs://${local.storage_source.bucket}/${local.storage_source.object}"
+    revision = data.google_cloud_run_service.function_service.status[0].latest_ready_revision_name
+  }
+
+  provisioner "local-exec" {
+    command = <<EOT
+      gcloud storage objects update '${self.triggers.source}' \
+        --update-custom-metadata=cloud-run-revision=${self.triggers.revision},cloud-function=${local.function_name} \
+        --quiet
+    EOT
+  }
+
+  lifecycle {
+    precondition {
+      condition     = local.storage_source != null
+      error_message = "The tag_source_with_revision requires a storage_source, url_source or inline_source. Sources from a repository are not stored in a bucket."
+    }
+  }
+}
+
+/******************************************
+	Eventarc Trigger with custom transport topic
+ *****************************************/
+data "google_project" "project" {
+  count = local.use_custom_transport || local.cross_project_building || local.manage_storage_iam ? 1 : 0
+
+  project_id = var.project_id
+}
+
+resource "google_eventarc_trigger" "function_trigger" {
+  count = local.use_custom_transport ? 1 : 0
+
+  name            = "trigger-${local.function_name}"
+  location        = var.event_trigger.trigger_region != null ? var.event_trigger.trigger_region : var.function_location
+  project         = var.project_id
+  service_account = var.event_trigger.service_account_email
+  labels          = merge(var.labels != null ? var.labels : {}, var.trigger_labels)
+
+  matching_criteria {
+    attribute = "type"
+    value     = var.event_trigger.event_type
+  }
+
+  dynamic "matching_criteria" {
+    for_each = var.event_trigger.event_filters != null ? var.event_trigger.event_filters : []
+    content {
+      attribute = matching_criteria.value.attribute
+      value     = matching_criteria.value.attribute_value
+      operator  = matching_criteria.value.operator
+    }
+  }
+
+  destination {
+    cloud_run_service {
+      service = local.cloud_run_service_name
+      region  = var.function_location
+    }
+  }
+
+  transport {
+    pubsub {
+      topic = var.event_trigger.transport_topic
+    }
+  }
+
+  depends_on = [
+    google_project_iam_member.eventarc_agent,
+    google_project_iam_member.trigger_event_receiver
+  ]
+}
+
+/******************************************
+	Storage trigger IAM
+ *****************************************/
+data "google_storage_project_service_account" "gcs_account" {
+  count = local.manage_storage_iam && local.storage_trigger ? 1 : 0
+
+  project = var.project_id
+}
+
+// The Cloud Storage service agent publishes the object events to the transport topic
+resource "google_project_iam_member" "storage_agent_publisher" {
+  count = local.manage_storage_iam && local.storage_trigger ? 1 : 0
+
+  project = var.project_id
+  role    = "roles/pubsub.publisher"
+  member  = "serviceAccount:${data.google_storage_project_service_account.gcs_account[0].email_address}"
+}
+
+resource "google_project_iam_member" "eventarc_agent" {
+  count = local.manage_storage_iam ? 1 : 0
+
+  project = var.project_id
+  role    = "roles/eventarc.serviceAgent"
+  member  = "serviceAccount:service-${data.google_project.project[0].number}@gcp-sa-eventarc.iam.gserviceaccount.com"
+}
+
+// Triggers without a service account use the Compute Engine default service account
+resource "google_project_iam_member" "trigger_event_receiver" {
+  count = local.manage_storage_iam ? 1 : 0
+
+  project = var.project_id
+  role    = "roles/eventarc.eventReceiver"
+  member  = "serviceAccount:${coalesce(var.event_trigger.service_account_email, "${data.google_project.project[0].number}-compute@developer.gserviceaccount.com")}"
+}
+
+// A custom transport trigger without a service account invokes the service as the Compute Engine default service
+// account, internal ingress triggers always have a service account
+resource "google_cloud_run_service_iam_member" "trigger_invoker" {
+  count = local.use_custom_transport || (var.event_trigger != null && local.internal_ingress) ? 1 : 0
+
+  location = var.function_location
+  project  = var.project_id
+  service  = local.cloud_run_service_name
+  role     = "roles/run.invoker"
+  member   = "serviceAccount:${var.event_trigger.service_account_email != null ? var.event_trigger.service_account_email : "${data.google_project.project[0].number}-compute@developer.gserviceaccount.com"}"
+}
+
+/******************************************
+	Cloud Build Worker Pool in another project
+ *****************************************/
+resource "google_project_iam_member" "worker_pool_user" {
+  for_each = toset(local.cross_project_building ? [
+    "serviceAccount:${data.google_project.project[0].number}@cloudbuild.gserviceaccount.com",
+    "serviceAccount:service-${data.google_project.project[0].number}@gcf-admin-robot.iam.gserviceaccount.com"
+  ] : [])
+
+  project = var.worker_pool_project_id
+  role    = "roles/cloudbuild.workerPoolUser"
+  member  = each.value
+}
+
+/******************************************
+	Data Access audit logs
+ *****************************************/
+// Cloud Functions (2nd Gen) requests are served and audited by the backing Cloud Run service
+resource "google_project_iam_audit_config" "run_audit_config" {
+  count = var.audit_log_config != null ? 1 : 0
+
+  project = var.project_id
+  service = "run.googleapis.com"
+
+  dynamic "audit_log_config" {
+    for_each = toset(var.audit_log_config.log_types)
+    content {
+      log_type         = audit_log_config.value
+      exempted_members = var.audit_log_config.exempted_members
+    }
+  }
+}
+
+/******************************************
+	Backing Cloud Run service settings
+ *****************************************/
+// Re-applied every time the function is updated, since redeploys reset the Cloud Run service
+resource "null_resource" "cloud_run_service_update" {
+  count = length(local.cloud_run_service_flags) > 0 || var.revision_suffix != null ? 1 : 0
+
+  triggers = {
+    function_update_time = google_cloudfunctions2_function.function.update_time
+    flags                = join(" ", local.cloud_run_service_flags)
+    revision_suffix      = var.revision_suffix == null ? "" : var.revision_suffix
+  }
+
+  // The revision suffix ends with a hash of the update time, only known once the function is deployed, so each
+  // deploy creates a revision with a new name
+  provisioner "local-exec" {
+    command = <<EOT
+      gcloud run services update ${local.cloud_run_service_name} \
+        --project=${var.project_id} \
+        --region=${var.function_location} \
+        ${join(" ", local.cloud_run_service_flags)} \
+        ${self.triggers.revision_suffix != "" ? "--revision-suffix=${self.triggers.revision_suffix}-${substr(sha256(self.triggers.function_update_time), 0, 6)}" : ""} \
+        --quiet
+    EOT
+  }
+}
+
+// Applied after the other settings, which can create the revision receiving the traffic
+resource "null_resource" "cloud_run_traffic_split" {
+  count = length(var.traffic_split) > 0 ? 1 : 0
+
+  triggers = {
+    function_update_time = google_cloudfunctions2_function.function.update_time
+    traffic              = join(",", [for revision, percent in var.traffic_split : "${revision}=${percent}"])
+  }
+
+  provisioner "local-exec" {
+    command = <<EOT
+      gcloud run services update-traffic ${local.cloud_run_service_name} \
+        --project=${var.project_id} \
+        --region=${var.function_location} \
+        --to-revisions=${self.triggers.traffic} \
+        --quiet
+    EOT
+  }
+
+  depends_on = [
+    null_resource.cloud_run_service_update
+  ]
+}
+
+data "google_cloud_run_service" "traffic" {
+  count = length(var.traffic_split) > 0 ? 1 : 0
+
+  name     = local.cloud_run_service_name
+  project  = var.project_id
+  location = var.function_location
+
+  depends_on = [
+    null_resource.cloud_run_traffic_split
+  ]
+}
+
+/******************************************
+	Configuration manifest
+ *****************************************/
+// Only the keys of the environment variables are written, so the manifest doesn't leak configuration values
+resource "local_file" "manifest" {
+  count = var.write_manifest ? 1 : 0
+
+  filename        = var.manifest_path == null ? "${path.root}/${local.function_name}-manifest.json" : var.manifest_path
+  file_permission = "0644"
+  content = jsonencode({
+    name                  = google_cloudfunctions2_function.function.name
+    project_id            = var.project_id
+    location              = var.function_location
+    runtime               = var.runtime
+    entrypoint            = var.entrypoint
+    uri                   = local.function_uri
+    service_account_email = google_cloudfunctions2_function.function.service_config[0].service_account_email
+    ingress_settings      = google_cloudfunctions2_function.function.service_config[0].ingress_settings
+    trigger = var.event_trigger == null ? { type = "http", event_type = null, pubsub_topic = null, retry_policy = null } : {
+      type         = "event"
+      event_type   = var.event_trigger.event_type
+      pubsub_topic = local.use_custom_transport ? var.event_trigger.transport_topic : var.event_trigger.pubsub_topic
+      retry_policy = var.event_trigger.retry_policy
+    }
+    environment_variable_keys        = sort(keys(local.runtime_env_variables))
+    secret_environment_variable_keys = sort([for secret in coalesce(try(tolist(var.service_config.runtime_secret_env_variables), null), []) : secret.key_name])
+    scaling = {
+      min_instance_count = google_cloudfunctions2_function.function.service_config[0].min_instance_count
+      max_instance_count = google_cloudfunctions2_function.function.service_config[0].max_instance_count
+      available_memory   = google_cloudfunctions2_function.function.service_config[0].available_memory
+      timeout_seconds    = google_cloudfunctions2_function.function.service_config[0].timeout_seconds
+    }
+  })
+}
@@ -0,0 +1,80 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+locals {
+  gateway_id = var.gateway_id == null ? "gw-${var.api_id}" : var.gateway_id
+}
+
+resource "google_cloud_run_service_iam_member" "gateway_invoker" {
+  location = var.function_location
+  project  = var.project_id
+  service  = var.function_service_name
+  role     = "roles/run.invoker"
+  member   = "serviceAccount:${var.service_account_email}"
+}
+
+resource "google_api_gateway_api" "api" {
+  provider = google-beta
+
+  api_id       = var.api_id
+  project      = var.project_id
+  display_name = var.display_name
+  labels       = var.labels
+}
+
+// The spec is rendered with the function_uri, so the x-google-backend address can reference the Cloud Function.
+// Terraform appends a 26 characters unique suffix to the prefix, so the api_id is truncated to keep the ID under 63 characters
+resource "google_api_gateway_api_config" "api_config" {
+  provider = google-beta
+
+  api                  = google_api_gateway_api.api.api_id
+  api_config_id_prefix = "cfg-${substr(var.api_id, 0, 32)}-"
+  project              = var.project_id
+  labels               = var.labels
+
+  openapi_documents {
+    document {
+      path     = basename(var.openapi_spec_path)
+      contents = base64encode(templatefile(var.openapi_spec_path, { function_uri = var.function_uri }))
+    }
+  }
+
+  gateway_config {
+    backend_config {
+      google_service_account = var.service_account_email
+    }
+  }
+
+  // A config can't be deleted while the gateway uses it
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+resource "google_api_gateway_gateway" "gateway" {
+  provider = google-beta
+
+  gateway_id   = local.gateway_id
+  project      = var.project_id
+  region       = var.region == null ? var.function_location : var.region
+  api_config   = google_api_gateway_api_config.api_config.id
+  display_name = var.display_name
+  labels       = var.labels
+
+  depends_on = [
+    google_cloud_run_service_iam_member.gateway_invoker
+  ]
+}
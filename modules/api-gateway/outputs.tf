@@ -0,0 +1,40 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+output "api_id" {
+  description = "The ID of the API."
+  value       = google_api_gateway_api.api.api_id
+}
+
+output "api_config_id" {
+  description = "The ID of the API config served by the gateway."
+  value       = google_api_gateway_api_config.api_config.id
+}
+
+output "gateway_id" {
+  description = "The ID of the gateway."
+  value       = google_api_gateway_gateway.gateway.id
+}
+
+output "default_hostname" {
+  description = "The default hostname of the gateway, like `<GATEWAY-ID>-<HASH>.<REGION>.gateway.dev`."
+  value       = google_api_gateway_gateway.gateway.default_hostname
+}
+
+output "managed_service" {
+  description = "The Service Infrastructure service of the API, which must be enabled to call the API with API keys."
+  value       = google_api_gateway_api.api.managed_service
+}
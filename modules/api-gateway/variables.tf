@@ -0,0 +1,79 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+variable "project_id" {
+  description = "The project ID where the Cloud Function and the API Gateway are deployed."
+  type        = string
+}
+
+variable "function_location" {
+  description = "The location of the Cloud Function used as the API backend."
+  type        = string
+}
+
+variable "function_service_name" {
+  description = "The name of the Cloud Run service backing the Cloud Function used as the API backend, like the `service_name` of the `connection` output of the root module."
+  type        = string
+}
+
+variable "function_uri" {
+  description = "The URI of the Cloud Function used as the API backend, available as `function_uri` in the OpenAPI spec."
+  type        = string
+}
+
+variable "api_id" {
+  description = "The ID of the API."
+  type        = string
+
+  validation {
+    condition     = can(regex("^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$", var.api_id))
+    error_message = "The api_id must have at most 63 lowercase letters, numbers and hyphens, and must start and end with a letter or number."
+  }
+}
+
+variable "openapi_spec_path" {
+  description = "The path of the OpenAPI 2.0 spec of the API. It is rendered as a template, so `$${function_uri}` can be used as the `x-google-backend` address and the `jwt_audience`."
+  type        = string
+}
+
+variable "service_account_email" {
+  description = "The service account used by the API Gateway to authenticate to the Cloud Function. It is granted the Cloud Run Invoker role on the Cloud Function."
+  type        = string
+}
+
+variable "gateway_id" {
+  description = "The ID of the gateway. Defaults to `gw-<API-ID>`."
+  type        = string
+  default     = null
+}
+
+variable "region" {
+  description = "The region of the gateway, which must be one of the API Gateway regions. Defaults to `function_location`."
+  type        = string
+  default     = null
+}
+
+variable "display_name" {
+  description = "The display name of the API and the gateway."
+  type        = string
+  default     = null
+}
+
+variable "labels" {
+  description = "Labels to be assigned to the API, the API config and the gateway."
+  type        = map(string)
+  default     = {}
+}
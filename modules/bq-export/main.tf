@@ -0,0 +1,66 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+locals {
+  dataset_project = var.dataset_project_id == null ? var.project_id : var.dataset_project_id
+  sink_name       = var.sink_name == null ? "sk-bq-${var.function_name}" : var.sink_name
+
+  // Cloud Functions (2nd Gen) requests are logged by the backing Cloud Run service
+  requests_filter = join(" AND ", [
+    "resource.type=\"cloud_run_revision\"",
+    "resource.labels.service_name=\"${lower(var.function_name)}\"",
+    "resource.labels.location=\"${var.function_location}\"",
+    "log_id(\"run.googleapis.com/requests\")"
+  ])
+}
+
+resource "google_bigquery_dataset" "function_dataset" {
+  count = var.create_dataset ? 1 : 0
+
+  project                     = local.dataset_project
+  dataset_id                  = var.dataset_id
+  location                    = var.dataset_location
+  description                 = "Request logs of the ${var.function_name} Cloud Function."
+  default_table_expiration_ms = var.table_expiration_days == null ? null : var.table_expiration_days * 24 * 60 * 60 * 1000
+  labels                      = var.labels
+}
+
+resource "google_logging_project_sink" "function_bq_sink" {
+  name                   = local.sink_name
+  project                = var.project_id
+  destination            = "bigquery.googleapis.com/projects/${local.dataset_project}/datasets/${var.dataset_id}"
+  filter                 = local.requests_filter
+  unique_writer_identity = true
+
+  bigquery_options {
+    use_partitioned_tables = true
+  }
+
+  depends_on = [
+    google_bigquery_dataset.function_dataset
+  ]
+}
+
+resource "google_bigquery_dataset_iam_member" "sink_dataset_editor" {
+  project    = local.dataset_project
+  dataset_id = var.dataset_id
+  role       = "roles/bigquery.dataEditor"
+  member     = google_logging_project_sink.function_bq_sink.writer_identity
+
+  depends_on = [
+    google_bigquery_dataset.function_dataset
+  ]
+}
@@ -0,0 +1,40 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+output "dataset_id" {
+  description = "The ID of the BigQuery dataset receiving the request logs, in the format `projects/<PROJECT-ID>/datasets/<DATASET-ID>`."
+  value       = "projects/${local.dataset_project}/datasets/${var.dataset_id}"
+}
+
+output "table_id" {
+  description = "The ID of the partitioned BigQuery table created by the log sink for the request logs."
+  value       = "${local.dataset_project}.${var.dataset_id}.run_googleapis_com_requests"
+}
+
+output "sink_name" {
+  description = "The name of the log sink exporting the request logs."
+  value       = google_logging_project_sink.function_bq_sink.name
+}
+
+output "sink_writer_identity" {
+  description = "The identity used by the log sink to write the request logs."
+  value       = google_logging_project_sink.function_bq_sink.writer_identity
+}
+
+output "filter" {
+  description = "The logging filter matching the Cloud Function request logs."
+  value       = local.requests_filter
+}
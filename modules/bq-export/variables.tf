@@ -0,0 +1,81 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+variable "project_id" {
+  description = "The project ID where the Cloud Function is deployed."
+  type        = string
+}
+
+variable "function_name" {
+  description = "The name of the Cloud Function whose request logs will be exported."
+  type        = string
+}
+
+variable "function_location" {
+  description = "The location of the Cloud Function whose request logs will be exported."
+  type        = string
+}
+
+variable "dataset_id" {
+  description = "The ID of the BigQuery dataset which will receive the request logs."
+  type        = string
+
+  validation {
+    condition     = can(regex("^[A-Za-z0-9_]{1,1024}$", var.dataset_id))
+    error_message = "The dataset_id must contain only letters, numbers and underscores."
+  }
+}
+
+variable "create_dataset" {
+  description = "Set to true to create the BigQuery dataset. When false, `dataset_id` must reference an existing dataset."
+  type        = bool
+  default     = true
+}
+
+variable "dataset_project_id" {
+  description = "The project where the BigQuery dataset is located. Defaults to `project_id`."
+  type        = string
+  default     = null
+}
+
+variable "dataset_location" {
+  description = "The location of the BigQuery dataset. Only used when `create_dataset` is true."
+  type        = string
+  default     = "US"
+}
+
+variable "table_expiration_days" {
+  description = "The number of days the request log tables are kept in the dataset. Only used when `create_dataset` is true. Defaults to never expire."
+  type        = number
+  default     = null
+
+  validation {
+    condition     = var.table_expiration_days == null ? true : var.table_expiration_days >= 1
+    error_message = "The table_expiration_days must be at least 1."
+  }
+}
+
+variable "sink_name" {
+  description = "The name of the log sink. Defaults to `sk-bq-<FUNCTION-NAME>`."
+  type        = string
+  default     = null
+}
+
+variable "labels" {
+  description = "Labels to be assigned to the BigQuery dataset."
+  type        = map(string)
+  default     = {}
+}
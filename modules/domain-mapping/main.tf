@@ -0,0 +1,39 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+locals {
+  // Cloud Functions (2nd Gen) are served by a Cloud Run service with the lowercase function name
+  service_name = lower(var.function_name)
+
+  conditions = try(google_cloud_run_domain_mapping.function_domain.status[0].conditions, [])
+}
+
+resource "google_cloud_run_domain_mapping" "function_domain" {
+  name     = var.domain
+  location = var.function_location
+  project  = var.project_id
+
+  metadata {
+    namespace = var.project_id
+    labels    = var.labels
+  }
+
+  spec {
+    route_name       = local.service_name
+    certificate_mode = var.certificate_mode
+    force_override   = var.force_override
+  }
+}
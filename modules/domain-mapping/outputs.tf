@@ -0,0 +1,39 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+output "domain" {
+  description = "The custom domain mapped to the Cloud Function."
+  value       = google_cloud_run_domain_mapping.function_domain.name
+}
+
+output "dns_records" {
+  description = "The DNS records which must be configured on the domain to serve the Cloud Function."
+  value = [for record in try(google_cloud_run_domain_mapping.function_domain.status[0].resource_records, []) : {
+    name   = record.name
+    type   = record.type
+    rrdata = record.rrdata
+  }]
+}
+
+output "certificate_provisioned" {
+  description = "The status of the managed certificate provisioning, `True` when the certificate is ready to serve the domain."
+  value       = try([for condition in local.conditions : condition.status if condition.type == "CertificateProvisioned"][0], "Unknown")
+}
+
+output "ready" {
+  description = "The status of the domain mapping, `True` when the domain is ready to serve the Cloud Function."
+  value       = try([for condition in local.conditions : condition.status if condition.type == "Ready"][0], "Unknown")
+}
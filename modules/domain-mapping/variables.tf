@@ -0,0 +1,63 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+variable "project_id" {
+  description = "The project ID where the Cloud Function is deployed."
+  type        = string
+}
+
+variable "function_name" {
+  description = "The name of the Cloud Function which will serve the domain."
+  type        = string
+}
+
+variable "function_location" {
+  description = "The location of the Cloud Function which will serve the domain."
+  type        = string
+}
+
+variable "domain" {
+  description = "The custom domain mapped to the Cloud Function, like `api.example.com`. The domain must be verified by the Terraform identity."
+  type        = string
+
+  validation {
+    condition     = can(regex("^([a-z0-9]([-a-z0-9]*[a-z0-9])?\\.)+[a-z]{2,}$", var.domain))
+    error_message = "The domain must be a valid lowercase domain name, like api.example.com."
+  }
+}
+
+variable "certificate_mode" {
+  description = "The mode of the certificate of the domain. Possible values: [\"NONE\", \"AUTOMATIC\"]. Use `AUTOMATIC` for a Google managed certificate."
+  type        = string
+  default     = "AUTOMATIC"
+
+  validation {
+    condition     = contains(["NONE", "AUTOMATIC"], var.certificate_mode)
+    error_message = "The certificate_mode must be NONE or AUTOMATIC."
+  }
+}
+
+variable "force_override" {
+  description = "Set to true to override an existing mapping of the domain."
+  type        = bool
+  default     = false
+}
+
+variable "labels" {
+  description = "Labels to be assigned to the domain mapping."
+  type        = map(string)
+  default     = {}
+}
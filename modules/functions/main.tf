@@ -0,0 +1,47 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+module "function" {
+  source   = "../../"
+  for_each = var.functions
+
+  project_id        = var.project_id
+  function_name     = each.value.name != null ? each.value.name : each.key
+  function_location = var.function_location
+  description       = each.value.description
+  labels            = var.labels
+  runtime           = each.value.runtime != null ? each.value.runtime : var.runtime
+  entrypoint        = each.value.entrypoint
+  event_trigger     = each.value.event_trigger
+  members           = var.members
+
+  require_authentication = var.require_authentication
+
+  storage_source = {
+    bucket = var.source_bucket
+    object = each.value.source_object
+  }
+
+  service_config = {
+    max_instance_count    = each.value.max_instance_count
+    min_instance_count    = each.value.min_instance_count
+    available_memory      = each.value.available_memory
+    timeout_seconds       = each.value.timeout_seconds
+    runtime_env_variables = merge(var.environment_variables, each.value.environment_variables)
+    service_account_email = var.service_account_email
+    ingress_settings      = var.ingress_settings
+  }
+}
@@ -0,0 +1,110 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+variable "project_id" {
+  description = "The project ID where the Cloud Functions will be deployed."
+  type        = string
+}
+
+variable "function_location" {
+  description = "The location of the Cloud Functions."
+  type        = string
+}
+
+variable "source_bucket" {
+  description = "The Cloud Storage bucket holding the source objects of all the Cloud Functions."
+  type        = string
+}
+
+variable "runtime" {
+  description = "The runtime of the Cloud Functions, like `go121`, unless overridden by a function."
+  type        = string
+}
+
+variable "functions" {
+  description = "The Cloud Functions to deploy. The map key is the function name, unless `name` is set. The fields not set use the defaults of the Cloud Function module."
+  type = map(object({
+    name               = optional(string)
+    description        = optional(string)
+    entrypoint         = string
+    runtime            = optional(string)
+    source_object      = string
+    available_memory   = optional(string)
+    timeout_seconds    = optional(number)
+    max_instance_count = optional(number)
+    min_instance_count = optional(number)
+    event_trigger = optional(object({
+      trigger_region        = optional(string)
+      event_type            = string
+      service_account_email = string
+      pubsub_topic          = optional(string)
+      transport_topic       = optional(string)
+      retry_policy          = string
+      event_filters = optional(set(object({
+        attribute       = string
+        attribute_value = string
+        operator        = optional(string)
+      })))
+    }))
+    environment_variables = optional(map(string), {})
+  }))
+
+  validation {
+    condition     = length(distinct([for key, function in var.functions : function.name != null ? function.name : key])) == length(var.functions)
+    error_message = "The function names must be unique."
+  }
+
+  validation {
+    condition     = alltrue([for key, function in var.functions : length(function.name != null ? function.name : key) <= 63])
+    error_message = "The function names must have at most 63 characters."
+  }
+}
+
+variable "environment_variables" {
+  description = "Runtime environment variables shared by all the Cloud Functions. The environment variables of a function take precedence on duplicated keys."
+  type        = map(string)
+  default     = {}
+}
+
+variable "service_account_email" {
+  description = "The service account used by all the Cloud Functions. Defaults to the Compute Engine default service account."
+  type        = string
+  default     = null
+}
+
+variable "ingress_settings" {
+  description = "The ingress settings of all the Cloud Functions. Possible values: [\"ALLOW_ALL\", \"ALLOW_INTERNAL_ONLY\", \"ALLOW_INTERNAL_AND_GCLB\"]."
+  type        = string
+  default     = null
+}
+
+variable "labels" {
+  description = "Labels to be assigned to all the Cloud Functions."
+  type        = map(string)
+  default     = null
+}
+
+variable "members" {
+  description = "Cloud Function Invoker and Developer roles for Users/SAs, granted on all the Cloud Functions. Key names must be developers and/or invokers."
+  type        = map(list(string))
+  default     = {}
+}
+
+variable "require_authentication" {
+  description = "Require authentication to invoke the Cloud Functions, rejecting allUsers and allAuthenticatedUsers in the invokers members. Set to false to allow public functions."
+  type        = bool
+  default     = true
+}
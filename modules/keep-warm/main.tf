@@ -0,0 +1,55 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+locals {
+  job_name = var.job_name == null ? "kw-${var.function_name}" : var.job_name
+}
+
+resource "google_cloud_run_service_iam_member" "scheduler_invoker" {
+  location = var.function_location
+  project  = var.project_id
+  service  = var.function_service_name
+  role     = "roles/run.invoker"
+  member   = "serviceAccount:${var.service_account_email}"
+}
+
+resource "google_cloud_scheduler_job" "keep_warm" {
+  name             = local.job_name
+  project          = var.project_id
+  region           = var.region == null ? var.function_location : var.region
+  description      = "Keeps the ${var.function_name} Cloud Function warm."
+  schedule         = var.schedule
+  time_zone        = var.time_zone
+  attempt_deadline = var.attempt_deadline
+
+  retry_config {
+    retry_count = 0
+  }
+
+  http_target {
+    http_method = var.http_method
+    uri         = "${var.function_uri}${var.path}"
+
+    oidc_token {
+      service_account_email = var.service_account_email
+      audience              = var.function_uri
+    }
+  }
+
+  depends_on = [
+    google_cloud_run_service_iam_member.scheduler_invoker
+  ]
+}
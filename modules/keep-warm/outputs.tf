@@ -0,0 +1,30 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+output "job_id" {
+  description = "The ID of the Cloud Scheduler job keeping the Cloud Function warm."
+  value       = google_cloud_scheduler_job.keep_warm.id
+}
+
+output "job_name" {
+  description = "The name of the Cloud Scheduler job keeping the Cloud Function warm."
+  value       = google_cloud_scheduler_job.keep_warm.name
+}
+
+output "schedule" {
+  description = "The cron schedule of the requests keeping the Cloud Function warm."
+  value       = google_cloud_scheduler_job.keep_warm.schedule
+}
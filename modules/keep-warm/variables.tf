@@ -0,0 +1,102 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+variable "project_id" {
+  description = "The project ID where the Cloud Function is deployed."
+  type        = string
+}
+
+variable "function_name" {
+  description = "The name of the Cloud Function which will be kept warm."
+  type        = string
+}
+
+variable "function_location" {
+  description = "The location of the Cloud Function which will be kept warm."
+  type        = string
+}
+
+variable "function_service_name" {
+  description = "The name of the Cloud Run service backing the Cloud Function which will be kept warm, like the `service_name` of the `connection` output of the root module."
+  type        = string
+}
+
+variable "function_uri" {
+  description = "The URI of the Cloud Function which will be kept warm, used as the OIDC token audience."
+  type        = string
+}
+
+variable "service_account_email" {
+  description = "The service account used by the Cloud Scheduler job to authenticate the requests. It is granted the Cloud Run Invoker role on the Cloud Function."
+  type        = string
+}
+
+variable "job_name" {
+  description = "The name of the Cloud Scheduler job. Defaults to `kw-<FUNCTION-NAME>`."
+  type        = string
+  default     = null
+}
+
+variable "region" {
+  description = "The region of the Cloud Scheduler job. Defaults to `function_location`."
+  type        = string
+  default     = null
+}
+
+variable "schedule" {
+  description = "The cron schedule of the requests keeping the Cloud Function warm."
+  type        = string
+  default     = "*/5 * * * *"
+}
+
+variable "time_zone" {
+  description = "The time zone of the `schedule`."
+  type        = string
+  default     = "Etc/UTC"
+}
+
+variable "http_method" {
+  description = "The HTTP method of the requests keeping the Cloud Function warm."
+  type        = string
+  default     = "GET"
+
+  validation {
+    condition     = contains(["GET", "HEAD", "POST", "OPTIONS"], var.http_method)
+    error_message = "The http_method must be one of GET, HEAD, POST or OPTIONS."
+  }
+}
+
+variable "path" {
+  description = "The path appended to the `function_uri` by the requests, like `/healthz`, so the Cloud Function can answer them without running its business logic."
+  type        = string
+  default     = ""
+
+  validation {
+    condition     = var.path == "" || can(regex("^/", var.path))
+    error_message = "The path must be empty or start with a slash."
+  }
+}
+
+variable "attempt_deadline" {
+  description = "How long the Cloud Scheduler job waits for the Cloud Function to respond, between `15s` and `1800s`."
+  type        = string
+  default     = "60s"
+
+  validation {
+    condition     = can(regex("^[0-9]+s$", var.attempt_deadline)) && try(tonumber(trimsuffix(var.attempt_deadline, "s")) >= 15 && tonumber(trimsuffix(var.attempt_deadline, "s")) <= 1800, false)
+    error_message = "The attempt_deadline must be a duration in seconds between 15s and 1800s, like 60s."
+  }
+}
@@ -0,0 +1,237 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+locals {
+  name     = var.name == null ? "lb-${lower(var.function_name)}" : var.name
+  external = var.lb_scheme == "EXTERNAL"
+  https    = length(var.ssl_certificates) > 0 || length(var.managed_ssl_domains) > 0
+  port     = local.https ? "443" : "80"
+
+  // Ingress settings which make the load balancer the only way to reach the function from outside the network
+  lb_ingress_settings = local.external ? ["ALLOW_INTERNAL_AND_GCLB"] : ["ALLOW_INTERNAL_ONLY", "ALLOW_INTERNAL_AND_GCLB"]
+  public_invokers     = length(setintersection(["allUsers", "allAuthenticatedUsers"], var.invoker_members)) > 0
+}
+
+// Cloud Functions (2nd Gen) are served by a Cloud Run service with the lowercase function name
+resource "google_compute_region_network_endpoint_group" "function_neg" {
+  name                  = "neg-${local.name}"
+  project               = var.project_id
+  region                = var.function_location
+  network_endpoint_type = "SERVERLESS"
+
+  cloud_run {
+    service = lower(var.function_name)
+  }
+
+  lifecycle {
+    precondition {
+      condition     = contains(local.lb_ingress_settings, var.function_ingress_settings)
+      error_message = "The function_ingress_settings must be ${join(" or ", local.lb_ingress_settings)} for a ${var.lb_scheme} load balancer. ALLOW_ALL lets the traffic bypass the load balancer, and ALLOW_INTERNAL_ONLY blocks external load balancers."
+    }
+
+    precondition {
+      condition     = !local.public_invokers || !var.function_require_authentication
+      error_message = "The invoker_members can't include allUsers or allAuthenticatedUsers when the function requires authentication. Set require_authentication to false on the function to make it public through the load balancer."
+    }
+  }
+}
+
+resource "google_cloud_run_service_iam_member" "invokers" {
+  for_each = toset(var.invoker_members)
+
+  location = var.function_location
+  project  = var.project_id
+  service  = lower(var.function_name)
+  role     = "roles/run.invoker"
+  member   = each.value
+}
+
+/******************************************
+	External Application Load Balancer
+ *****************************************/
+resource "google_compute_backend_service" "external" {
+  count = local.external ? 1 : 0
+
+  name                  = "bs-${local.name}"
+  project               = var.project_id
+  load_balancing_scheme = "EXTERNAL_MANAGED"
+  protocol              = "HTTPS"
+
+  backend {
+    group = google_compute_region_network_endpoint_group.function_neg.id
+  }
+}
+
+resource "google_compute_url_map" "external" {
+  count = local.external ? 1 : 0
+
+  name            = "um-${local.name}"
+  project         = var.project_id
+  default_service = google_compute_backend_service.external[0].id
+}
+
+resource "google_compute_managed_ssl_certificate" "external" {
+  count = local.external && length(var.managed_ssl_domains) > 0 ? 1 : 0
+
+  name    = "cert-${local.name}"
+  project = var.project_id
+
+  managed {
+    domains = var.managed_ssl_domains
+  }
+}
+
+resource "google_compute_ssl_policy" "external" {
+  count = local.external && var.min_tls_version != null ? 1 : 0
+
+  name            = "ssl-${local.name}"
+  project         = var.project_id
+  min_tls_version = var.min_tls_version
+  profile         = var.ssl_policy_profile
+
+  lifecycle {
+    precondition {
+      condition     = local.https
+      error_message = "The min_tls_version requires ssl_certificates or managed_ssl_domains, since the load balancer only serves HTTP without certificates."
+    }
+  }
+}
+
+resource "google_compute_target_https_proxy" "external" {
+  count = local.external && local.https ? 1 : 0
+
+  name             = "https-${local.name}"
+  project          = var.project_id
+  url_map          = google_compute_url_map.external[0].id
+  ssl_certificates = concat(var.ssl_certificates, google_compute_managed_ssl_certificate.external[*].id)
+  ssl_policy       = one(google_compute_ssl_policy.external[*].id)
+}
+
+resource "google_compute_target_http_proxy" "external" {
+  count = local.external && !local.https ? 1 : 0
+
+  name    = "http-${local.name}"
+  project = var.project_id
+  url_map = google_compute_url_map.external[0].id
+}
+
+resource "google_compute_global_address" "external" {
+  count = local.external ? 1 : 0
+
+  name    = "ip-${local.name}"
+  project = var.project_id
+}
+
+resource "google_compute_global_forwarding_rule" "external" {
+  count = local.external ? 1 : 0
+
+  name                  = "fr-${local.name}"
+  project               = var.project_id
+  load_balancing_scheme = "EXTERNAL_MANAGED"
+  ip_address            = google_compute_global_address.external[0].id
+  port_range            = local.port
+  target                = local.https ? google_compute_target_https_proxy.external[0].id : google_compute_target_http_proxy.external[0].id
+  labels                = var.labels
+}
+
+/******************************************
+	Internal Regional Application Load Balancer
+ *****************************************/
+resource "google_compute_region_backend_service" "internal" {
+  count = local.external ? 0 : 1
+
+  name                  = "bs-${local.name}"
+  project               = var.project_id
+  region                = var.function_location
+  load_balancing_scheme = "INTERNAL_MANAGED"
+  protocol              = "HTTPS"
+
+  backend {
+    group           = google_compute_region_network_endpoint_group.function_neg.id
+    balancing_mode  = "UTILIZATION"
+    capacity_scaler = 1.0
+  }
+
+  lifecycle {
+    precondition {
+      condition     = var.network != null && var.subnetwork != null
+      error_message = "The network and subnetwork are required when the lb_scheme is INTERNAL."
+    }
+
+    precondition {
+      condition     = var.min_tls_version == null
+      error_message = "The min_tls_version is only supported when the lb_scheme is EXTERNAL."
+    }
+
+    precondition {
+      condition     = length(var.managed_ssl_domains) == 0
+      error_message = "Google managed certificates are only supported when the lb_scheme is EXTERNAL. Use ssl_certificates with regional certificates for INTERNAL load balancers."
+    }
+  }
+}
+
+resource "google_compute_region_url_map" "internal" {
+  count = local.external ? 0 : 1
+
+  name            = "um-${local.name}"
+  project         = var.project_id
+  region          = var.function_location
+  default_service = google_compute_region_backend_service.internal[0].id
+}
+
+resource "google_compute_region_target_https_proxy" "internal" {
+  count = !local.external && local.https ? 1 : 0
+
+  name             = "https-${local.name}"
+  project          = var.project_id
+  region           = var.function_location
+  url_map          = google_compute_region_url_map.internal[0].id
+  ssl_certificates = var.ssl_certificates
+}
+
+resource "google_compute_region_target_http_proxy" "internal" {
+  count = !local.external && !local.https ? 1 : 0
+
+  name    = "http-${local.name}"
+  project = var.project_id
+  region  = var.function_location
+  url_map = google_compute_region_url_map.internal[0].id
+}
+
+resource "google_compute_address" "internal" {
+  count = local.external ? 0 : 1
+
+  name         = "ip-${local.name}"
+  project      = var.project_id
+  region       = var.function_location
+  address_type = "INTERNAL"
+  subnetwork   = var.subnetwork
+}
+
+resource "google_compute_forwarding_rule" "internal" {
+  count = local.external ? 0 : 1
+
+  name                  = "fr-${local.name}"
+  project               = var.project_id
+  region                = var.function_location
+  load_balancing_scheme = "INTERNAL_MANAGED"
+  network               = var.network
+  subnetwork            = var.subnetwork
+  ip_address            = google_compute_address.internal[0].id
+  port_range            = local.port
+  target                = local.https ? google_compute_region_target_https_proxy.internal[0].id : google_compute_region_target_http_proxy.internal[0].id
+  labels                = var.labels
+}
@@ -0,0 +1,30 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+output "ip_address" {
+  description = "The IP address of the load balancer, internal to the VPC when `lb_scheme` is `INTERNAL`."
+  value       = local.external ? google_compute_global_address.external[0].address : google_compute_address.internal[0].address
+}
+
+output "backend_service_id" {
+  description = "The ID of the backend service serving the Cloud Function."
+  value       = local.external ? google_compute_backend_service.external[0].id : google_compute_region_backend_service.internal[0].id
+}
+
+output "network_endpoint_group_id" {
+  description = "The ID of the serverless network endpoint group of the Cloud Function."
+  value       = google_compute_region_network_endpoint_group.function_neg.id
+}
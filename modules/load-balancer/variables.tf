@@ -0,0 +1,115 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+variable "project_id" {
+  description = "The project ID where the Cloud Function is deployed."
+  type        = string
+}
+
+variable "function_name" {
+  description = "The name of the Cloud Function served by the load balancer."
+  type        = string
+}
+
+variable "function_location" {
+  description = "The location of the Cloud Function served by the load balancer. Internal load balancers are created in this region."
+  type        = string
+}
+
+variable "function_ingress_settings" {
+  description = "The ingress settings of the Cloud Function, the `ingress_settings` output of the root module. The load balancer is only created when the ingress keeps external traffic from bypassing it: `ALLOW_INTERNAL_AND_GCLB` for `EXTERNAL`, and `ALLOW_INTERNAL_ONLY` or `ALLOW_INTERNAL_AND_GCLB` for `INTERNAL` load balancers."
+  type        = string
+}
+
+variable "function_require_authentication" {
+  description = "Whether the Cloud Function requires authentication, the `require_authentication` output of the root module. The `invoker_members` can't include `allUsers` or `allAuthenticatedUsers` when it is `true`."
+  type        = bool
+}
+
+variable "invoker_members" {
+  description = "Members granted the Cloud Run Invoker role on the backing Cloud Run service, like `allUsers` for a public load balancer, since the load balancer forwards the requests without authenticating them. Granting `allUsers` or `allAuthenticatedUsers` requires a function with `require_authentication` set to `false`."
+  type        = list(string)
+  default     = []
+}
+
+variable "name" {
+  description = "The name used by the load balancer resources. Defaults to `lb-<FUNCTION-NAME>`."
+  type        = string
+  default     = null
+}
+
+variable "lb_scheme" {
+  description = "The scheme of the load balancer. Possible values: [\"EXTERNAL\", \"INTERNAL\"]. `EXTERNAL` creates a global external Application Load Balancer and `INTERNAL` a regional internal Application Load Balancer, only reachable from the VPC."
+  type        = string
+  default     = "EXTERNAL"
+
+  validation {
+    condition     = contains(["EXTERNAL", "INTERNAL"], var.lb_scheme)
+    error_message = "The lb_scheme must be EXTERNAL or INTERNAL."
+  }
+}
+
+variable "network" {
+  description = "The self link of the VPC network of the internal load balancer. Required when `lb_scheme` is `INTERNAL`."
+  type        = string
+  default     = null
+}
+
+variable "subnetwork" {
+  description = "The self link of the subnetwork where the internal load balancer IP is reserved. Required when `lb_scheme` is `INTERNAL`."
+  type        = string
+  default     = null
+}
+
+variable "ssl_certificates" {
+  description = "The self links of the SSL certificates of the load balancer. Regional certificates must be used when `lb_scheme` is `INTERNAL`. The load balancer serves HTTP when no certificate is provided."
+  type        = list(string)
+  default     = []
+}
+
+variable "managed_ssl_domains" {
+  description = "The domains of a Google managed SSL certificate created for the load balancer. Only supported when `lb_scheme` is `EXTERNAL`."
+  type        = list(string)
+  default     = []
+}
+
+variable "labels" {
+  description = "Labels to be assigned to the forwarding rule."
+  type        = map(string)
+  default     = {}
+}
+
+variable "min_tls_version" {
+  description = "The minimum TLS version accepted by the HTTPS load balancer. Possible values: [\"TLS_1_0\", \"TLS_1_1\", \"TLS_1_2\"]. Only supported when `lb_scheme` is `EXTERNAL`. Defaults to the Google Cloud default SSL policy."
+  type        = string
+  default     = null
+
+  validation {
+    condition     = var.min_tls_version == null ? true : contains(["TLS_1_0", "TLS_1_1", "TLS_1_2"], var.min_tls_version)
+    error_message = "The min_tls_version must be TLS_1_0, TLS_1_1 or TLS_1_2."
+  }
+}
+
+variable "ssl_policy_profile" {
+  description = "The profile of the SSL policy, selecting the enabled cipher suites. Possible values: [\"COMPATIBLE\", \"MODERN\", \"RESTRICTED\"]. Only used when `min_tls_version` is set."
+  type        = string
+  default     = "MODERN"
+
+  validation {
+    condition     = contains(["COMPATIBLE", "MODERN", "RESTRICTED"], var.ssl_policy_profile)
+    error_message = "The ssl_policy_profile must be COMPATIBLE, MODERN or RESTRICTED."
+  }
+}
@@ -0,0 +1,78 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+locals {
+  log_bucket_project = var.log_bucket_project_id == null ? var.project_id : var.log_bucket_project_id
+  log_bucket_name    = "projects/${local.log_bucket_project}/locations/${var.log_bucket_location}/buckets/${var.log_bucket_id}"
+  sink_name          = var.sink_name == null ? "sk-${var.function_name}" : var.sink_name
+
+  // Cloud Functions (2nd Gen) logs are written by the backing Cloud Run service
+  function_filter = join(" OR ", [
+    "(resource.type=\"cloud_run_revision\" AND resource.labels.service_name=\"${lower(var.function_name)}\" AND resource.labels.location=\"${var.function_location}\")",
+    "(resource.type=\"cloud_function\" AND resource.labels.function_name=\"${var.function_name}\" AND resource.labels.region=\"${var.function_location}\")"
+  ])
+  sink_filter = var.min_log_severity == null ? local.function_filter : "(${local.function_filter}) AND severity>=${var.min_log_severity}"
+}
+
+resource "google_logging_project_bucket_config" "function_log_bucket" {
+  count = var.create_log_bucket ? 1 : 0
+
+  project        = local.log_bucket_project
+  location       = var.log_bucket_location
+  bucket_id      = var.log_bucket_id
+  retention_days = var.retention_days
+  description    = "Log bucket for the ${var.function_name} Cloud Function logs."
+}
+
+resource "google_logging_project_sink" "function_sink" {
+  name                   = local.sink_name
+  project                = var.project_id
+  destination            = "logging.googleapis.com/${local.log_bucket_name}"
+  filter                 = local.sink_filter
+  unique_writer_identity = true
+
+  depends_on = [
+    google_logging_project_bucket_config.function_log_bucket
+  ]
+}
+
+resource "google_project_iam_member" "sink_bucket_writer" {
+  count = local.log_bucket_project != var.project_id ? 1 : 0
+
+  project = local.log_bucket_project
+  role    = "roles/logging.bucketWriter"
+  member  = google_logging_project_sink.function_sink.writer_identity
+}
+
+// Keeps the routed logs from also being stored in the _Default bucket
+resource "google_logging_project_exclusion" "default_exclusion" {
+  count = var.exclude_from_default_bucket ? 1 : 0
+
+  name        = "ex-${local.sink_name}"
+  project     = var.project_id
+  description = "Excludes the ${var.function_name} Cloud Function logs from the _Default bucket."
+  filter      = local.function_filter
+}
+
+// Drops the logs below min_log_severity when the Cloud Function logs are still stored in the _Default bucket
+resource "google_logging_project_exclusion" "severity_exclusion" {
+  count = var.min_log_severity != null && !var.exclude_from_default_bucket ? 1 : 0
+
+  name        = "ex-sev-${local.sink_name}"
+  project     = var.project_id
+  description = "Excludes the ${var.function_name} Cloud Function logs below ${var.min_log_severity} from the _Default bucket."
+  filter      = "(${local.function_filter}) AND severity<${var.min_log_severity}"
+}
@@ -0,0 +1,35 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+output "sink_name" {
+  description = "The name of the log sink routing the Cloud Function logs."
+  value       = google_logging_project_sink.function_sink.name
+}
+
+output "sink_writer_identity" {
+  description = "The identity used by the log sink to write the Cloud Function logs."
+  value       = google_logging_project_sink.function_sink.writer_identity
+}
+
+output "log_bucket_name" {
+  description = "The full name of the log bucket receiving the Cloud Function logs."
+  value       = local.log_bucket_name
+}
+
+output "filter" {
+  description = "The logging filter matching the Cloud Function logs."
+  value       = local.function_filter
+}
@@ -0,0 +1,87 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+variable "project_id" {
+  description = "The project ID where the Cloud Function is deployed."
+  type        = string
+}
+
+variable "function_name" {
+  description = "The name of the Cloud Function whose logs will be routed."
+  type        = string
+}
+
+variable "function_location" {
+  description = "The location of the Cloud Function whose logs will be routed."
+  type        = string
+}
+
+variable "sink_name" {
+  description = "The name of the log sink. Defaults to `sk-<FUNCTION-NAME>`."
+  type        = string
+  default     = null
+}
+
+variable "create_log_bucket" {
+  description = "Set to true to create the log bucket. When false, `log_bucket_id` must reference an existing bucket."
+  type        = bool
+  default     = true
+}
+
+variable "log_bucket_project_id" {
+  description = "The project where the log bucket is located. Defaults to `project_id`."
+  type        = string
+  default     = null
+}
+
+variable "log_bucket_id" {
+  description = "The ID of the log bucket which will receive the Cloud Function logs."
+  type        = string
+}
+
+variable "log_bucket_location" {
+  description = "The location of the log bucket."
+  type        = string
+  default     = "global"
+}
+
+variable "retention_days" {
+  description = "The number of days the Cloud Function logs are retained in the log bucket. Only used when `create_log_bucket` is true."
+  type        = number
+  default     = 7
+
+  validation {
+    condition     = var.retention_days >= 1 && var.retention_days <= 3650
+    error_message = "The retention_days must be between 1 and 3650."
+  }
+}
+
+variable "exclude_from_default_bucket" {
+  description = "Set to true to exclude the Cloud Function logs from the `_Default` log bucket."
+  type        = bool
+  default     = true
+}
+
+variable "min_log_severity" {
+  description = "The minimum severity of the Cloud Function logs that are stored, one of DEBUG, INFO, NOTICE, WARNING, ERROR, CRITICAL, ALERT or EMERGENCY. Logs below it, including the logs without a severity, are dropped before storage. Defaults to keeping all logs."
+  type        = string
+  default     = null
+
+  validation {
+    condition     = var.min_log_severity == null ? true : contains(["DEBUG", "INFO", "NOTICE", "WARNING", "ERROR", "CRITICAL", "ALERT", "EMERGENCY"], var.min_log_severity)
+    error_message = "The min_log_severity must be one of DEBUG, INFO, NOTICE, WARNING, ERROR, CRITICAL, ALERT or EMERGENCY."
+  }
+}
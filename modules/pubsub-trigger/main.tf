@@ -0,0 +1,58 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+locals {
+  subscription_name = var.subscription_name == null ? "sub-${var.function_name}" : var.subscription_name
+}
+
+resource "google_cloud_run_service_iam_member" "push_invoker" {
+  location = var.function_location
+  project  = var.project_id
+  service  = var.function_service_name
+  role     = "roles/run.invoker"
+  member   = "serviceAccount:${var.service_account_email}"
+}
+
+resource "google_pubsub_subscription" "function_subscription" {
+  name    = local.subscription_name
+  project = var.project_id
+  topic   = var.topic
+  labels  = var.labels
+
+  ack_deadline_seconds       = var.ack_deadline_seconds
+  retain_acked_messages      = var.retain_acked_messages
+  message_retention_duration = var.message_retention_duration
+
+  dynamic "expiration_policy" {
+    for_each = var.expiration_ttl == null ? [] : [var.expiration_ttl]
+    content {
+      ttl = expiration_policy.value
+    }
+  }
+
+  push_config {
+    push_endpoint = var.function_uri
+
+    oidc_token {
+      service_account_email = var.service_account_email
+      audience              = var.function_uri
+    }
+  }
+
+  depends_on = [
+    google_cloud_run_service_iam_member.push_invoker
+  ]
+}
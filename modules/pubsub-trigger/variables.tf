@@ -0,0 +1,101 @@
+/**
+ * Copyright 2026 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+variable "project_id" {
+  description = "The project ID where the Cloud Function is deployed."
+  type        = string
+}
+
+variable "function_name" {
+  description = "The name of the Cloud Function which will receive the messages."
+  type        = string
+}
+
+variable "function_location" {
+  description = "The location of the Cloud Function which will receive the messages."
+  type        = string
+}
+
+variable "function_service_name" {
+  description = "The name of the Cloud Run service backing the Cloud Function which will receive the messages, like the `service_name` of the `connection` output of the root module."
+  type        = string
+}
+
+variable "function_uri" {
+  description = "The URI of the Cloud Function which will receive the messages, used as the push endpoint and the OIDC token audience."
+  type        = string
+}
+
+variable "topic" {
+  description = "The ID of the Pub/Sub topic, in the format `projects/<PROJECT-ID>/topics/<TOPIC>`."
+  type        = string
+}
+
+variable "service_account_email" {
+  description = "The service account used by the subscription to authenticate the push requests. It is granted the Cloud Run Invoker role on the Cloud Function."
+  type        = string
+}
+
+variable "subscription_name" {
+  description = "The name of the Pub/Sub subscription. Defaults to `sub-<FUNCTION-NAME>`."
+  type        = string
+  default     = null
+}
+
+variable "ack_deadline_seconds" {
+  description = "The number of seconds the Cloud Function has to acknowledge a message before it is redelivered, between 10 and 600. Defaults to the Pub/Sub default."
+  type        = number
+  default     = null
+
+  validation {
+    condition     = var.ack_deadline_seconds == null ? true : var.ack_deadline_seconds >= 10 && var.ack_deadline_seconds <= 600
+    error_message = "The ack_deadline_seconds must be between 10 and 600."
+  }
+}
+
+variable "retain_acked_messages" {
+  description = "Set to true to retain the acknowledged messages for the `message_retention_duration`, allowing them to be replayed with a seek."
+  type        = bool
+  default     = false
+}
+
+variable "message_retention_duration" {
+  description = "How long the unacknowledged messages, and the acknowledged ones when `retain_acked_messages` is true, are retained, between `600s` and `604800s`. Defaults to the Pub/Sub default."
+  type        = string
+  default     = null
+
+  validation {
+    condition     = var.message_retention_duration == null ? true : can(regex("^[0-9]+s$", var.message_retention_duration)) && try(tonumber(trimsuffix(var.message_retention_duration, "s")) >= 600 && tonumber(trimsuffix(var.message_retention_duration, "s")) <= 604800, false)
+    error_message = "The message_retention_duration must be a duration in seconds between 600s and 604800s, like 86400s."
+  }
+}
+
+variable "expiration_ttl" {
+  description = "How long the subscription can be inactive before it is deleted, like `2678400s`. An empty string means the subscription never expires. Defaults to the Pub/Sub default."
+  type        = string
+  default     = null
+
+  validation {
+    condition     = var.expiration_ttl == null ? true : can(regex("^([0-9]+s)?$", var.expiration_ttl))
+    error_message = "The expiration_ttl must be a duration in seconds, like 2678400s, or an empty string."
+  }
+}
+
+variable "labels" {
+  description = "Labels to be assigned to the Pub/Sub subscription."
+  type        = map(string)
+  default     = {}
+}
@@ -16,6 +16,8 @@
 
 locals {
   project_number = var.project_number == null ? data.google_project.project.number : var.project_number
+  bucket_name    = "${var.name_prefix}gcf-v2-sources-${local.project_number}-${var.location}${var.name_suffix}"
+  repository_id  = "rep-cloud-function-${var.name_prefix}${var.function_name}${var.name_suffix}"
 }
 
 data "google_project" "project" {
@@ -28,12 +30,13 @@ module "cloudfunction_bucket" {
 
   project_id      = var.project_id
   labels          = var.labels
-  name            = "gcf-v2-sources-${local.project_number}-${var.location}"
+  name            = local.bucket_name
   location        = var.location
   storage_class   = "REGIONAL"
   force_destroy   = var.force_destroy
   cors            = var.bucket_cors
   lifecycle_rules = var.bucket_lifecycle_rules
+  versioning      = var.bucket_versioning
 
   encryption = {
     default_kms_key_name = var.encryption_key
@@ -50,11 +53,41 @@ resource "google_eventarc_google_channel_config" "primary" {
 resource "google_artifact_registry_repository" "cloudfunction_repo" {
   location      = var.location
   project       = var.project_id
-  repository_id = "rep-cloud-function-${var.function_name}"
+  repository_id = local.repository_id
   description   = "This repo stores de image of the secure cloud function"
   format        = "DOCKER"
   kms_key_name  = var.encryption_key
   labels        = var.labels
+
+  lifecycle {
+    precondition {
+      condition     = length(local.bucket_name) <= 63
+      error_message = "The source bucket name, ${local.bucket_name}, must have at most 63 characters. Use a shorter name_prefix or name_suffix."
+    }
+
+    precondition {
+      condition     = length(local.repository_id) <= 63
+      error_message = "The Artifact Registry repository ID, ${local.repository_id}, must have at most 63 characters. Use a shorter function_name, name_prefix or name_suffix."
+    }
+  }
+}
+
+// Destroy-time provisioners can only reference self, so the repository is kept in the triggers
+resource "null_resource" "cloudfunction_repo_cleanup" {
+  count = var.force_destroy_artifact_registry ? 1 : 0
+
+  triggers = {
+    repository = "${var.location}-docker.pkg.dev/${var.project_id}/${google_artifact_registry_repository.cloudfunction_repo.repository_id}"
+  }
+
+  provisioner "local-exec" {
+    when    = destroy
+    command = <<EOT
+      for image in $(gcloud artifacts docker images list ${self.triggers.repository} --include-tags --format='value[separator="@"](package,version)'); do
+        gcloud artifacts docker images delete $image --delete-tags --quiet
+      done
+    EOT
+  }
 }
 
 resource "google_project_service" "container_scanning_api" {
@@ -94,6 +127,8 @@ module "cloud_function" {
   source = "../../"
 
   function_name       = var.function_name
+  name_prefix         = var.name_prefix
+  name_suffix         = var.name_suffix
   description         = var.function_description
   project_id          = var.project_id
   labels              = var.labels
@@ -105,6 +140,8 @@ module "cloud_function" {
   event_trigger       = var.event_trigger
   storage_source      = var.storage_source
   service_config      = var.service_config
+  inject_metadata_env = var.inject_metadata_env
+  cloudsql_instances  = var.cloudsql_instances
   docker_repository   = google_artifact_registry_repository.cloudfunction_repo.id
   worker_pool         = google_cloudbuild_worker_pool.pool.id
 
@@ -48,3 +48,8 @@ output "cloudfunction_url" {
   value       = module.cloud_function.function_uri
   description = "The URL on which the deployed service is available."
 }
+
+output "build_image_uri" {
+  value       = module.cloud_function.build_image_uri
+  description = "The URI of the container image built from the Cloud Function source."
+}
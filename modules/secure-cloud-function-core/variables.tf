@@ -40,6 +40,28 @@ variable "function_name" {
   type        = string
 }
 
+variable "name_prefix" {
+  description = "Prefix added to the Cloud Function name, the source bucket name and the Artifact Registry repository ID, like `dev-`. Used to deploy the same function in multiple environments of a project."
+  type        = string
+  default     = ""
+
+  validation {
+    condition     = can(regex("^([a-z][a-z0-9-]*)?$", var.name_prefix))
+    error_message = "The name_prefix must start with a lowercase letter and contain only lowercase letters, numbers and hyphens."
+  }
+}
+
+variable "name_suffix" {
+  description = "Suffix added to the Cloud Function name, the source bucket name and the Artifact Registry repository ID, like `-dev`. Used to deploy the same function in multiple environments of a project."
+  type        = string
+  default     = ""
+
+  validation {
+    condition     = can(regex("^[a-z0-9-]*$", var.name_suffix))
+    error_message = "The name_suffix must contain only lowercase letters, numbers and hyphens."
+  }
+}
+
 variable "function_description" {
   description = "The description of the Cloud Function to create."
   type        = string
@@ -69,13 +91,18 @@ variable "entry_point" {
 }
 
 variable "storage_source" {
-  description = "Get the source from this location in Google Cloud Storage."
+  description = "Get the source from this location in Google Cloud Storage. Set `generation` to pin the deploy to a specific object generation."
   type = object({
     bucket     = string
     object     = string
     generation = optional(string, null)
   })
   default = null
+
+  validation {
+    condition     = var.storage_source == null ? true : var.storage_source.generation == null ? true : can(regex("^[1-9][0-9]*$", var.storage_source.generation))
+    error_message = "The storage_source generation must be a positive integer."
+  }
 }
 
 variable "repo_source" {
@@ -104,6 +131,7 @@ variable "event_trigger" {
     event_type            = string
     service_account_email = string
     pubsub_topic          = optional(string)
+    transport_topic       = optional(string)
     retry_policy          = string
     event_filters = optional(set(object({
       attribute       = string
@@ -151,6 +179,24 @@ variable "force_destroy" {
   default     = false
 }
 
+variable "inject_metadata_env" {
+  description = "Set to true to add the GCP_PROJECT, FUNCTION_REGION and FUNCTION_NAME environment variables with the project ID, location and name of the function. They can be overridden by the other environment variables."
+  type        = bool
+  default     = false
+}
+
+variable "cloudsql_instances" {
+  description = "Connection names, like `<PROJECT-ID>:<REGION>:<INSTANCE>`, of the Cloud SQL instances attached to the backing Cloud Run service of the function. Each instance is reachable through a Unix socket at `/cloudsql/<CONNECTION-NAME>`. Applied with the `gcloud` CLI."
+  type        = list(string)
+  default     = []
+}
+
+variable "force_destroy_artifact_registry" {
+  description = "Delete the images of the Artifact Registry repository, using the `gcloud` CLI, before the repository is destroyed. Intended for ephemeral environments."
+  type        = bool
+  default     = false
+}
+
 variable "bucket_cors" {
   description = "Configuration of CORS for bucket with structure as defined in https://registry.terraform.io/providers/hashicorp/google/latest/docs/resources/storage_bucket#cors."
   type        = any
@@ -170,6 +216,12 @@ variable "bucket_cors" {
   }]
 }
 
+variable "bucket_versioning" {
+  description = "Set to false to disable object versioning on the Cloud Function source bucket. Noncurrent versions are billed as regular objects until deleted by `bucket_lifecycle_rules`, which by default keeps only the 3 newest archived versions."
+  type        = bool
+  default     = true
+}
+
 variable "bucket_lifecycle_rules" {
   description = "The bucket's Lifecycle Rules configuration."
   type = list(object({
@@ -26,6 +26,10 @@ terraform {
       source  = "hashicorp/google-beta"
       version = "< 5.0"
     }
+    null = {
+      source  = "hashicorp/null"
+      version = "3.2.0"
+    }
   }
   provider_meta "google" {
     module_name = "blueprints/terraform/terraform-google-cloud-functions:secure-cloud-function-core/v0.3.0"
@@ -14,6 +14,19 @@
  * limitations under the License.
  */
 
+locals {
+  key_users = [
+    "serviceAccount:${google_project_service_identity.cloudfunction_sa.email}",
+    "serviceAccount:${var.service_account_email}",
+    "serviceAccount:${google_project_service_identity.artifact_sa.email}",
+    "serviceAccount:${google_project_service_identity.eventarc_sa.email}",
+    "serviceAccount:${data.google_storage_project_service_account.gcs_account.email_address}",
+    "serviceAccount:${google_project_service_identity.pubsub_sa.email}"
+  ]
+
+  // The label holds the number of the primary version of the key, the last segment of its name
+  labels = var.redeploy_on_key_rotation ? merge(var.labels, { "kms-key-rotation" = element(reverse(split("/", data.google_kms_crypto_key.key[0].primary[0].name)), 0) }) : var.labels
+}
 
 module "cloud_serverless_network" {
   source  = "GoogleCloudPlatform/cloud-run/google//modules/secure-serverless-net"
@@ -34,8 +47,100 @@ module "cloud_serverless_network" {
   serverless_service_identity_email = google_project_service_identity.cloudfunction_sa.email
 }
 
+locals {
+  connector_name = element(split("/", module.cloud_serverless_network.connector_id), 5)
+  connector_tag  = "vpc-connector-${var.location}-${local.connector_name}"
+}
+
+// The VPC Connector instances are tagged with vpc-connector-<REGION>-<CONNECTOR-NAME>
+resource "google_compute_firewall" "allow_function_egress" {
+  count = var.egress_allowed_cidrs != null ? 1 : 0
+
+  name               = "fw-allow-egress-${local.connector_name}"
+  project            = var.vpc_project_id
+  network            = var.shared_vpc_name
+  direction          = "EGRESS"
+  priority           = 1000
+  target_tags        = [local.connector_tag]
+  destination_ranges = var.egress_allowed_cidrs
+
+  allow {
+    protocol = "all"
+  }
+}
+
+resource "google_compute_firewall" "deny_function_egress" {
+  count = var.egress_allowed_cidrs != null ? 1 : 0
+
+  name               = "fw-deny-egress-${local.connector_name}"
+  project            = var.vpc_project_id
+  network            = var.shared_vpc_name
+  direction          = "EGRESS"
+  priority           = 1100
+  target_tags        = [local.connector_tag]
+  destination_ranges = ["0.0.0.0/0"]
+
+  deny {
+    protocol = "all"
+  }
+}
+
+// Cloud NAT for the VPC Connector subnet, so the Cloud Function egress to the internet uses a single reserved IP
+resource "google_compute_address" "static_egress_ip" {
+  count = var.enable_static_egress ? 1 : 0
+
+  name         = "ip-egress-${local.connector_name}"
+  project      = var.vpc_project_id
+  region       = var.location
+  address_type = "EXTERNAL"
+}
+
+resource "google_compute_router" "static_egress_router" {
+  count = var.enable_static_egress ? 1 : 0
+
+  name    = "cr-egress-${local.connector_name}"
+  project = var.vpc_project_id
+  region  = var.location
+  network = var.shared_vpc_name
+}
+
+resource "google_compute_router_nat" "static_egress_nat" {
+  count = var.enable_static_egress ? 1 : 0
+
+  name                               = "nat-egress-${local.connector_name}"
+  project                            = var.vpc_project_id
+  region                             = var.location
+  router                             = google_compute_router.static_egress_router[0].name
+  nat_ip_allocate_option             = "MANUAL_ONLY"
+  nat_ips                            = [google_compute_address.static_egress_ip[0].self_link]
+  source_subnetwork_ip_ranges_to_nat = "LIST_OF_SUBNETWORKS"
+
+  subnetwork {
+    name                    = "projects/${var.vpc_project_id}/regions/${var.location}/subnetworks/${var.subnet_name}"
+    source_ip_ranges_to_nat = ["ALL_IP_RANGES"]
+  }
+
+  lifecycle {
+    precondition {
+      condition     = var.subnet_name != null
+      error_message = "The subnet_name is required when enable_static_egress is true, so the Cloud NAT can be applied to the VPC Connector subnet."
+    }
+
+    precondition {
+      condition     = var.vpc_egress_value == "ALL_TRAFFIC"
+      error_message = "The vpc_egress_value must be ALL_TRAFFIC when enable_static_egress is true, otherwise the internet traffic doesn't go through the VPC Connector."
+    }
+  }
+
+  depends_on = [
+    module.cloud_serverless_network
+  ]
+}
+
+// The project is explicit, taken from the email of user-managed service accounts, instead of the provider default
 data "google_service_account" "cloud_serverless_sa" {
   account_id = var.service_account_email
+  project    = try(regex("@([a-z][a-z0-9-]+)\\.iam\\.gserviceaccount\\.com$", var.service_account_email)[0], var.serverless_project_id)
 }
 
 resource "google_service_account_iam_member" "identity_service_account_user" {
@@ -92,45 +197,45 @@ module "cloud_function_security" {
   organization_id       = var.organization_id
   groups                = var.groups
 
-  encrypters = [
-    "serviceAccount:${google_project_service_identity.cloudfunction_sa.email}",
-    "serviceAccount:${var.service_account_email}",
-    "serviceAccount:${google_project_service_identity.artifact_sa.email}",
-    "serviceAccount:${google_project_service_identity.eventarc_sa.email}",
-    "serviceAccount:${data.google_storage_project_service_account.gcs_account.email_address}",
-    "serviceAccount:${google_project_service_identity.pubsub_sa.email}"
-  ]
+  encrypters = local.key_users
+  decrypters = local.key_users
+}
 
-  decrypters = [
-    "serviceAccount:${google_project_service_identity.cloudfunction_sa.email}",
-    "serviceAccount:${var.service_account_email}",
-    "serviceAccount:${google_project_service_identity.artifact_sa.email}",
-    "serviceAccount:${google_project_service_identity.eventarc_sa.email}",
-    "serviceAccount:${data.google_storage_project_service_account.gcs_account.email_address}",
-    "serviceAccount:${google_project_service_identity.pubsub_sa.email}"
-  ]
+// The primary version of the key is read on each plan, so a rotation, scheduled by KMS from the rotation period of the
+// key or made manually, changes the kms-key-rotation label and redeploys the Cloud Function on the next apply
+data "google_kms_crypto_key" "key" {
+  count = var.redeploy_on_key_rotation ? 1 : 0
+
+  name     = var.key_name
+  key_ring = module.cloud_function_security.keyring_self_link
 }
 
 module "cloud_function_core" {
   source = "../secure-cloud-function-core"
 
-  function_name               = var.function_name
-  function_description        = var.function_description
-  project_id                  = var.serverless_project_id
-  project_number              = var.serverless_project_number
-  labels                      = var.labels
-  location                    = var.location
-  runtime                     = var.runtime
-  entry_point                 = var.entry_point
-  repo_source                 = var.repo_source
-  storage_source              = var.storage_source
-  build_environment_variables = var.build_environment_variables
-  event_trigger               = var.event_trigger
-  force_destroy               = !var.prevent_destroy
-  encryption_key              = module.cloud_function_security.key_self_link
-  bucket_lifecycle_rules      = var.bucket_lifecycle_rules
-  bucket_cors                 = var.bucket_cors
-  network_id                  = var.network_id
+  function_name                   = var.function_name
+  name_prefix                     = var.name_prefix
+  name_suffix                     = var.name_suffix
+  function_description            = var.function_description
+  project_id                      = var.serverless_project_id
+  project_number                  = var.serverless_project_number
+  labels                          = local.labels
+  location                        = var.location
+  runtime                         = var.runtime
+  entry_point                     = var.entry_point
+  repo_source                     = var.repo_source
+  storage_source                  = var.storage_source
+  build_environment_variables     = var.build_environment_variables
+  event_trigger                   = var.event_trigger
+  force_destroy                   = !var.prevent_destroy
+  force_destroy_artifact_registry = var.force_destroy_artifact_registry
+  inject_metadata_env             = var.inject_metadata_env
+  cloudsql_instances              = var.cloudsql_instances
+  encryption_key                  = module.cloud_function_security.key_self_link
+  bucket_lifecycle_rules          = var.bucket_lifecycle_rules
+  bucket_versioning               = var.bucket_versioning
+  bucket_cors                     = var.bucket_cors
+  network_id                      = var.network_id
 
   service_config = {
     max_instance_count             = var.max_scale_instances
@@ -142,7 +247,7 @@ module "cloud_function_core" {
     ingress_settings               = var.ingress_settings
     all_traffic_on_latest_revision = var.all_traffic_on_latest_revision
     vpc_connector_egress_settings  = var.vpc_egress_value
-    runtime_env_variables          = var.environment_variables
+    runtime_env_variables          = merge(concat(var.environment_variables_layers, [{ for k, v in var.environment_variables_any : k => tostring(v) }, var.environment_variables])...)
 
     runtime_secret_env_variables = var.secret_environment_variables
     secret_volumes               = var.secret_volumes
@@ -63,3 +63,13 @@ output "serverless_identity_services_sa" {
   value       = google_project_service_identity.cloudfunction_sa.email
   description = "Service Identity to serverless services."
 }
+
+output "build_image_uri" {
+  value       = module.cloud_function_core.build_image_uri
+  description = "The URI of the container image built from the Cloud Function source."
+}
+
+output "static_egress_ip" {
+  value       = var.enable_static_egress ? google_compute_address.static_egress_ip[0].address : null
+  description = "The static external IP used by the Cloud Function egress to the internet, when `enable_static_egress` is true."
+}
@@ -56,6 +56,28 @@ variable "function_name" {
   type        = string
 }
 
+variable "name_prefix" {
+  description = "Prefix added to the Cloud Function name, the source bucket name and the Artifact Registry repository ID, like `dev-`. Used to deploy the same function in multiple environments of a project."
+  type        = string
+  default     = ""
+
+  validation {
+    condition     = can(regex("^([a-z][a-z0-9-]*)?$", var.name_prefix))
+    error_message = "The name_prefix must start with a lowercase letter and contain only lowercase letters, numbers and hyphens."
+  }
+}
+
+variable "name_suffix" {
+  description = "Suffix added to the Cloud Function name, the source bucket name and the Artifact Registry repository ID, like `-dev`. Used to deploy the same function in multiple environments of a project."
+  type        = string
+  default     = ""
+
+  validation {
+    condition     = can(regex("^[a-z0-9-]*$", var.name_suffix))
+    error_message = "The name_suffix must contain only lowercase letters, numbers and hyphens."
+  }
+}
+
 variable "function_description" {
   description = "Cloud Function description."
   type        = string
@@ -95,6 +117,18 @@ variable "environment_variables" {
   description = "A set of key/value environment variable pairs to assign to the function."
 }
 
+variable "environment_variables_layers" {
+  description = "A list of maps of environment variables merged in order, later layers taking precedence, like a base map followed by per-environment overrides. The environment_variables_any and the environment_variables are merged after the layers."
+  type        = list(map(string))
+  default     = []
+}
+
+variable "environment_variables_any" {
+  type        = map(any)
+  default     = {}
+  description = "A set of key/value environment variable pairs with number or bool values, like `MAX_CONN = 10`, converted to strings and assigned to the function. The `environment_variables` take precedence on duplicated keys."
+}
+
 variable "build_environment_variables" {
   type        = map(string)
   default     = {}
@@ -107,6 +141,7 @@ variable "event_trigger" {
     event_type            = string
     service_account_email = string
     pubsub_topic          = optional(string)
+    transport_topic       = optional(string)
     retry_policy          = string
     event_filters = optional(set(object({
       attribute       = string
@@ -123,6 +158,24 @@ variable "prevent_destroy" {
   default     = true
 }
 
+variable "inject_metadata_env" {
+  description = "Set to true to add the GCP_PROJECT, FUNCTION_REGION and FUNCTION_NAME environment variables with the project ID, location and name of the function. They can be overridden by the other environment variables."
+  type        = bool
+  default     = false
+}
+
+variable "cloudsql_instances" {
+  description = "Connection names, like `<PROJECT-ID>:<REGION>:<INSTANCE>`, of the Cloud SQL instances attached to the backing Cloud Run service of the function. Each instance is reachable through a Unix socket at `/cloudsql/<CONNECTION-NAME>`. Applied with the `gcloud` CLI."
+  type        = list(string)
+  default     = []
+}
+
+variable "force_destroy_artifact_registry" {
+  description = "Delete the images of the Artifact Registry repository, using the `gcloud` CLI, before the repository is destroyed. Intended for ephemeral environments."
+  type        = bool
+  default     = false
+}
+
 variable "keyring_name" {
   description = "Keyring name."
   type        = string
@@ -135,6 +188,12 @@ variable "key_rotation_period" {
   default     = "2592000s"
 }
 
+variable "redeploy_on_key_rotation" {
+  description = "Set to true to redeploy the Cloud Function when the primary version of the KMS key changes, after a scheduled or manual rotation, so the build uses the new key version. The redeploy is forced through the `kms-key-rotation` label, holding the number of the primary version."
+  type        = bool
+  default     = false
+}
+
 variable "key_protection_level" {
   description = "The protection level to use when creating a version based on this template. Possible values: [\"SOFTWARE\", \"HSM\"]"
   type        = string
@@ -195,13 +254,18 @@ variable "repo_source" {
 }
 
 variable "storage_source" {
-  description = "Get the source from this location in Google Cloud Storage."
+  description = "Get the source from this location in Google Cloud Storage. Set `generation` to pin the deploy to a specific object generation."
   type = object({
     bucket     = string
     object     = string
     generation = optional(string, null)
   })
   default = null
+
+  validation {
+    condition     = var.storage_source == null ? true : var.storage_source.generation == null ? true : can(regex("^[1-9][0-9]*$", var.storage_source.generation))
+    error_message = "The storage_source generation must be a positive integer."
+  }
 }
 
 variable "labels" {
@@ -216,6 +280,18 @@ variable "resource_names_suffix" {
   default     = null
 }
 
+variable "egress_allowed_cidrs" {
+  description = "CIDR ranges the Cloud Function is allowed to reach through the VPC Connector, like an on-premises database range. When set, all the other egress traffic from the VPC Connector is denied by firewall rules on the Shared VPC, so include the ranges of any other destination used by the function, like the Private Google Access range. Defaults to allow all egress traffic."
+  type        = list(string)
+  default     = null
+}
+
+variable "enable_static_egress" {
+  description = "Set to true to create a Cloud Router and a Cloud NAT with a reserved external IP for the VPC Connector subnet, so the Cloud Function reaches the internet from a static source IP. Requires `subnet_name` and `vpc_egress_value` set to `ALL_TRAFFIC`."
+  type        = bool
+  default     = false
+}
+
 variable "max_scale_instances" {
   description = "Sets the maximum number of container instances needed to handle all incoming requests or events from each revison from Cloud Run. For more information, access this [documentation](https://cloud.google.com/run/docs/about-instance-autoscaling)."
   type        = number
@@ -261,6 +337,21 @@ variable "secret_environment_variables" {
   }))
   default     = null
   description = "A list of maps which contains key, project_id, secret_name (not the full secret id) and version to assign to the function as a set of secret environment variables."
+
+  validation {
+    condition     = alltrue([for env in(var.secret_environment_variables == null ? [] : tolist(var.secret_environment_variables)) : can(regex("^[a-zA-Z0-9_-]{1,255}$", env.secret))])
+    error_message = "The secret of the secret_environment_variables must be a secret name, not the full secret ID, with at most 255 letters, numbers, underscores and hyphens."
+  }
+
+  validation {
+    condition     = alltrue([for env in(var.secret_environment_variables == null ? [] : tolist(var.secret_environment_variables)) : can(regex("^(latest|[1-9][0-9]*)$", env.version))])
+    error_message = "The version of the secret_environment_variables must be latest or a positive integer."
+  }
+
+  validation {
+    condition     = alltrue([for env in(var.secret_environment_variables == null ? [] : tolist(var.secret_environment_variables)) : env.project_id == null ? true : can(regex("^(([a-z][a-z0-9.-]*[a-z0-9]:)?[a-z][a-z0-9-]{4,28}[a-z0-9]|[0-9]+)$", env.project_id))])
+    error_message = "The project_id of the secret_environment_variables must be a project ID or number. Leave it unset to use the function project."
+  }
 }
 
 variable "secret_volumes" {
@@ -275,6 +366,21 @@ variable "secret_volumes" {
   }))
   description = "[Beta] Environment variables (Secret Manager)."
   default     = null
+
+  validation {
+    condition     = alltrue([for volume in(var.secret_volumes == null ? [] : tolist(var.secret_volumes)) : can(regex("^[a-zA-Z0-9_-]{1,255}$", volume.secret))])
+    error_message = "The secret of the secret_volumes must be a secret name, not the full secret ID, with at most 255 letters, numbers, underscores and hyphens."
+  }
+
+  validation {
+    condition     = alltrue(flatten([for volume in(var.secret_volumes == null ? [] : tolist(var.secret_volumes)) : [for v in volume.versions : can(regex("^(latest|[1-9][0-9]*)$", v.version))]]))
+    error_message = "The version of the secret_volumes must be latest or a positive integer."
+  }
+
+  validation {
+    condition     = alltrue([for volume in(var.secret_volumes == null ? [] : tolist(var.secret_volumes)) : volume.project_id == null ? true : can(regex("^(([a-z][a-z0-9.-]*[a-z0-9]:)?[a-z][a-z0-9-]{4,28}[a-z0-9]|[0-9]+)$", volume.project_id))])
+    error_message = "The project_id of the secret_volumes must be a project ID or number. Leave it unset to use the function project."
+  }
 }
 
 variable "groups" {
@@ -316,6 +422,12 @@ variable "bucket_cors" {
   }]
 }
 
+variable "bucket_versioning" {
+  description = "Set to false to disable object versioning on the Cloud Function source bucket. Noncurrent versions are billed as regular objects until deleted by `bucket_lifecycle_rules`, which by default keeps only the 3 newest archived versions."
+  type        = bool
+  default     = true
+}
+
 variable "bucket_lifecycle_rules" {
   description = "The bucket's Lifecycle Rules configuration."
   type = list(object({
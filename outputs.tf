@@ -16,10 +16,133 @@
 
 output "function_uri" {
   description = "URI of the Cloud Function (Gen 2)"
-  value       = google_cloudfunctions2_function.function.service_config[0].uri
+  value       = local.function_uri
+}
+
+output "connection" {
+  description = "Everything needed to invoke the Cloud Function from another module: the uri, function id, service_account_email, region, project and the backing Cloud Run service name"
+  value = {
+    uri                   = local.function_uri
+    id                    = google_cloudfunctions2_function.function.id
+    service_account_email = google_cloudfunctions2_function.function.service_config[0].service_account_email
+    region                = google_cloudfunctions2_function.function.location
+    project               = google_cloudfunctions2_function.function.project
+    service_name          = local.cloud_run_service_name
+  }
 }
 
 output "function_name" {
   description = "Name of the Cloud Function (Gen 2)"
-  value       = var.function_name
+  value       = google_cloudfunctions2_function.function.name
+}
+
+output "min_instance_count" {
+  description = "Effective minimum number of instances kept warm for the Cloud Function"
+  value       = try(google_cloudfunctions2_function.function.service_config[0].min_instance_count, 0)
+}
+
+output "build_id" {
+  description = "Cloud Build name of the latest successful build of the Cloud Function"
+  value       = google_cloudfunctions2_function.function.build_config[0].build
+}
+
+output "build_image_uri" {
+  description = "URI of the container image built from the function source"
+  value       = try(data.google_cloud_run_service.function_service.template[0].spec[0].containers[0].image, null)
+}
+
+output "source_revision" {
+  description = "Source object of the Cloud Function and the latest ready revision of the backing Cloud Run service deployed from it, to trace a source artifact to its revision"
+  value = {
+    bucket   = try(local.storage_source.bucket, null)
+    object   = try(local.storage_source.object, null)
+    revision = try(data.google_cloud_run_service.function_service.status[0].latest_ready_revision_name, null)
+  }
+}
+
+output "nfs_mount_paths" {
+  description = "Paths where the NFS volumes are mounted on the backing Cloud Run service"
+  value       = [for nfs in var.nfs_volumes : nfs.mount_path]
+}
+
+output "invoke_command" {
+  description = "Command to invoke the Cloud Function, a curl with an identity token for HTTP functions or gcloud functions call for event-driven functions"
+  value = var.event_trigger == null ? (
+    "curl -H \"Authorization: bearer $(gcloud auth print-identity-token)\" ${local.function_uri}"
+    ) : (
+    "gcloud functions call ${local.function_name} --gen2 --region ${var.function_location} --project ${var.project_id}"
+  )
+}
+
+output "oidc_audience" {
+  description = "Audience of the identity tokens used to invoke the Cloud Function, the function URL"
+  value       = local.function_uri
+}
+
+output "curl_command" {
+  description = "Command to invoke the Cloud Function from a service account, minting an identity token for the oidc_audience"
+  value       = "curl -H \"Authorization: Bearer $(gcloud auth print-identity-token --audiences=${local.function_uri})\" ${local.function_uri}"
+}
+
+output "function_deployment_metadata" {
+  description = "Deployment metadata recorded in the labels of the function"
+  value       = local.deployment_labels
+}
+
+output "service_account_email" {
+  description = "Email of the service account used by the Cloud Function"
+  value       = google_cloudfunctions2_function.function.service_config[0].service_account_email
+}
+
+output "ingress_settings" {
+  description = "Ingress settings of the Cloud Function, used by the load-balancer submodule to validate the function is only reachable through the load balancer"
+  value       = google_cloudfunctions2_function.function.service_config[0].ingress_settings
+}
+
+output "require_authentication" {
+  description = "Whether the Cloud Function requires authentication, used by the load-balancer submodule to validate its invoker members"
+  value       = var.require_authentication
+}
+
+output "is_public" {
+  description = "Whether the Cloud Function can be invoked by allUsers or allAuthenticatedUsers"
+  value       = length(setintersection(["allUsers", "allAuthenticatedUsers"], lookup(var.members, "invokers", []))) > 0
+}
+
+output "required_apis" {
+  description = "APIs required by the features configured in the Cloud Function"
+  value       = local.required_apis
+}
+
+output "manifest_path" {
+  description = "Path of the configuration manifest, when write_manifest is true"
+  value       = var.write_manifest ? local_file.manifest[0].filename : null
+}
+
+output "traffic_allocation" {
+  description = "Percent of the traffic served by each revision of the backing Cloud Run service, when traffic_split is set"
+  value = length(var.traffic_split) > 0 ? [
+    for traffic in data.google_cloud_run_service.traffic[0].status[0].traffic : {
+      revision_name   = traffic.revision_name
+      percent         = traffic.percent
+      latest_revision = traffic.latest_revision
+    }
+  ] : null
+}
+
+output "trigger_match_criteria" {
+  description = "Event type, filters, Pub/Sub topic and service account of the Eventarc trigger of the Cloud Function, for debugging triggers which don't fire. Null for HTTP functions"
+  value = var.event_trigger == null ? null : local.use_custom_transport ? {
+    trigger               = google_eventarc_trigger.function_trigger[0].name
+    event_type            = one([for criteria in google_eventarc_trigger.function_trigger[0].matching_criteria : criteria.value if criteria.attribute == "type"])
+    event_filters         = [for criteria in google_eventarc_trigger.function_trigger[0].matching_criteria : { attribute = criteria.attribute, value = criteria.value, operator = try(criteria.operator, null) } if criteria.attribute != "type"]
+    pubsub_topic          = try(google_eventarc_trigger.function_trigger[0].transport[0].pubsub[0].topic, null)
+    service_account_email = google_eventarc_trigger.function_trigger[0].service_account
+    } : {
+    trigger               = google_cloudfunctions2_function.function.event_trigger[0].trigger
+    event_type            = google_cloudfunctions2_function.function.event_trigger[0].event_type
+    event_filters         = [for filter in google_cloudfunctions2_function.function.event_trigger[0].event_filters : { attribute = filter.attribute, value = filter.value, operator = filter.operator }]
+    pubsub_topic          = google_cloudfunctions2_function.function.event_trigger[0].pubsub_topic
+    service_account_email = google_cloudfunctions2_function.function.event_trigger[0].service_account_email
+  }
 }
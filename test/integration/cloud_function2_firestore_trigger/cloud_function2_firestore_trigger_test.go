@@ -0,0 +1,55 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloud_function2_firestore_trigger
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/cloud-foundation-toolkit/infra/blueprint-test/pkg/gcloud"
+	"github.com/GoogleCloudPlatform/cloud-foundation-toolkit/infra/blueprint-test/pkg/tft"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGCF2FirestoreTrigger(t *testing.T) {
+	firestore_triggerT := tft.NewTFBlueprintTest(t)
+
+	firestore_triggerT.DefineVerify(func(assert *assert.Assertions) {
+		firestore_triggerT.DefaultVerify(assert)
+
+		function_name := firestore_triggerT.GetStringOutput("function_name")
+		databaseName := firestore_triggerT.GetStringOutput("database_name")
+		documentPattern := firestore_triggerT.GetStringOutput("document_path_pattern")
+		projectID := firestore_triggerT.GetStringOutput("project_id")
+		function_location := firestore_triggerT.GetStringOutput("function_location")
+
+		function_cmd := gcloud.Run(t, "functions describe", gcloud.WithCommonArgs([]string{function_name, "--project", projectID, "--gen2", "--region", function_location, "--format", "json"}))
+
+		// T01: Verify if the Cloud Functions deployed is in ACTIVE state
+		assert.Equal("ACTIVE", function_cmd.Get("state").String(), fmt.Sprintf("Should be ACTIVE. Cloud Function is not successfully deployed."))
+
+		// T02: Verify if the Cloud Functions is triggered by the Firestore document events
+		assert.Equal("google.cloud.firestore.document.v1.written", function_cmd.Get("eventTrigger.eventType").String(), fmt.Sprintf("Event Trigger is not based on Firestore document events. Check the EventType configuration."))
+
+		// T03: Verify if the Event Trigger filters match the database and the document path pattern
+		filters := map[string]string{}
+		for _, filter := range function_cmd.Get("eventTrigger.eventFilters").Array() {
+			filters[filter.Get("attribute").String()] = filter.Get("value").String()
+		}
+		assert.Equal(databaseName, filters["database"], fmt.Sprintf("Event Trigger database filter should be %s.", databaseName))
+		assert.Equal(documentPattern, filters["document"], fmt.Sprintf("Event Trigger document filter should be %s.", documentPattern))
+	})
+	firestore_triggerT.Test()
+}
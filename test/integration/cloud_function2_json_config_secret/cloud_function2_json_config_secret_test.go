@@ -0,0 +1,62 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cloud_function2_json_config_secret
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/cloud-foundation-toolkit/infra/blueprint-test/pkg/gcloud"
+	"github.com/GoogleCloudPlatform/cloud-foundation-toolkit/infra/blueprint-test/pkg/tft"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGCF2JSONConfigSecret(t *testing.T) {
+	configT := tft.NewTFBlueprintTest(t)
+
+	configT.DefineVerify(func(assert *assert.Assertions) {
+		configT.DefaultVerify(assert)
+
+		function_name := configT.GetStringOutput("function_name")
+		secretID := configT.GetStringOutput("config_secret_id")
+		mountPath := configT.GetStringOutput("config_mount_path")
+		projectID := configT.GetStringOutput("project_id")
+		function_location := configT.GetStringOutput("function_location")
+
+		function_cmd := gcloud.Run(t, "functions describe", gcloud.WithCommonArgs([]string{function_name, "--project", projectID, "--gen2", "--region", function_location, "--format", "json"}))
+
+		// T01: Verify if the Cloud Functions deployed is in ACTIVE state
+		assert.Equal("ACTIVE", function_cmd.Get("state").String(), fmt.Sprintf("Should be ACTIVE. Cloud Function is not successfully deployed."))
+
+		// T02: Verify if the config secret is mounted as a volume of the function
+		volume := function_cmd.Get("serviceConfig.secretVolumes.0")
+		assert.Equal(secretID, volume.Get("secret").String(), fmt.Sprintf("Secret volume should mount %s.", secretID))
+		assert.Equal(mountPath, volume.Get("mountPath").String(), fmt.Sprintf("Secret volume should be mounted at %s.", mountPath))
+		assert.Equal("config.json", volume.Get("versions.0.path").String(), "Secret volume should expose the config as config.json.")
+
+		// T03: Verify if the function service account can access the config secret
+		functionSA := function_cmd.Get("serviceConfig.serviceAccountEmail").String()
+		policy_cmd := gcloud.Run(t, "secrets get-iam-policy", gcloud.WithCommonArgs([]string{secretID, "--project", projectID, "--format", "json"}))
+		accessors := []string{}
+		for _, binding := range policy_cmd.Get("bindings").Array() {
+			if binding.Get("role").String() == "roles/secretmanager.secretAccessor" {
+				for _, member := range binding.Get("members").Array() {
+					accessors = append(accessors, member.String())
+				}
+			}
+		}
+		assert.Contains(accessors, fmt.Sprintf("serviceAccount:%s", functionSA), "Function service account should have the Secret Manager Secret Accessor role.")
+	})
+	configT.Test()
+}
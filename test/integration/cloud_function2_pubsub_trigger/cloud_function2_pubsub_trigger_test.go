@@ -43,6 +43,42 @@ func TestGCF2PubSubTrigger(t *testing.T) {
 		// Topic format: projects/<PROJECT_ID>/topic/<TOPICNAME>
 		// Output: <TOPICNAME>
 		assert.Contains(function_cmd.Get("eventTrigger.pubsubTopic").String(), pubsubTopic, fmt.Sprintf("Event Trigger is not based on PubSub Topic provided in variables. Check the EventType configuration."))
+
+		runtimeSA := pubsub_triggerT.GetStringOutput("runtime_service_account_email")
+		triggerSA := pubsub_triggerT.GetStringOutput("trigger_service_account_email")
+
+		// T03: Verify if the runtime service account has only the project roles configured by the example
+		assert.ElementsMatch([]string{"roles/logging.logWriter"}, projectRoles(t, projectID, runtimeSA), "Runtime service account should only have the configured project roles.")
+
+		// T04: Verify if the trigger service account has no project roles
+		assert.Empty(projectRoles(t, projectID, triggerSA), "Trigger service account should not have project roles.")
+
+		// T05: Verify if only the trigger service account can invoke the backing Cloud Run service, and no public binding was granted
+		service_policy_cmd := gcloud.Run(t, "run services get-iam-policy", gcloud.WithCommonArgs([]string{function_cmd.Get("serviceConfig.service").String(), "--project", projectID, "--region", function_location, "--format", "json"}))
+		serviceMembers := map[string][]string{}
+		for _, binding := range service_policy_cmd.Get("bindings").Array() {
+			for _, member := range binding.Get("members").Array() {
+				serviceMembers[binding.Get("role").String()] = append(serviceMembers[binding.Get("role").String()], member.String())
+			}
+		}
+		assert.Equal(map[string][]string{"roles/run.invoker": {fmt.Sprintf("serviceAccount:%s", triggerSA)}}, serviceMembers, "Backing Cloud Run service should only grant the Cloud Run Invoker role to the trigger service account.")
+
+		function_policy_cmd := gcloud.Run(t, "functions get-iam-policy", gcloud.WithCommonArgs([]string{function_name, "--project", projectID, "--gen2", "--region", function_location, "--format", "json"}))
+		for _, binding := range function_policy_cmd.Get("bindings").Array() {
+			for _, member := range binding.Get("members").Array() {
+				assert.NotContains([]string{"allUsers", "allAuthenticatedUsers"}, member.String(), fmt.Sprintf("Cloud Function should not grant %s to %s.", binding.Get("role").String(), member.String()))
+			}
+		}
 	})
 	pubsub_triggerT.Test()
 }
+
+// projectRoles returns the project roles granted to the service account.
+func projectRoles(t *testing.T, projectID, serviceAccount string) []string {
+	policy := gcloud.Run(t, "projects get-iam-policy", gcloud.WithCommonArgs([]string{projectID, "--flatten", "bindings[].members", "--filter", fmt.Sprintf("bindings.members:serviceAccount:%s", serviceAccount), "--format", "json"}))
+	roles := []string{}
+	for _, binding := range policy.Array() {
+		roles = append(roles, binding.Get("bindings.role").String())
+	}
+	return roles
+}
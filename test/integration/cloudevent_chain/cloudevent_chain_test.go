@@ -0,0 +1,57 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudevent_chain
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/cloud-foundation-toolkit/infra/blueprint-test/pkg/gcloud"
+	"github.com/GoogleCloudPlatform/cloud-foundation-toolkit/infra/blueprint-test/pkg/tft"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGCF2CloudEventChain(t *testing.T) {
+	chainT := tft.NewTFBlueprintTest(t)
+
+	chainT.DefineVerify(func(assert *assert.Assertions) {
+		chainT.DefaultVerify(assert)
+
+		projectID := chainT.GetStringOutput("project_id")
+		function_location := chainT.GetStringOutput("function_location")
+		outputTopic := chainT.GetStringOutput("output_topic")
+
+		// Each stage is triggered by its own topic, the output topic of the first stage being the input of the second
+		stages := map[string]string{
+			chainT.GetStringOutput("process_function_name"): chainT.GetStringOutput("input_topic"),
+			chainT.GetStringOutput("handle_function_name"):  outputTopic,
+		}
+
+		for function_name, topic := range stages {
+			function_cmd := gcloud.Run(t, "functions describe", gcloud.WithCommonArgs([]string{function_name, "--project", projectID, "--gen2", "--region", function_location, "--format", "json"}))
+
+			// T01: Verify if the Cloud Functions deployed is in ACTIVE state
+			assert.Equal("ACTIVE", function_cmd.Get("state").String(), fmt.Sprintf("Should be ACTIVE. Cloud Function %s is not successfully deployed.", function_name))
+
+			// T02: Verify if the Cloud Function is triggered by the topic of its stage
+			assert.Equal(topic, function_cmd.Get("eventTrigger.pubsubTopic").String(), fmt.Sprintf("Cloud Function %s should be triggered by %s.", function_name, topic))
+		}
+
+		// T03: Verify if the first stage publishes to the topic triggering the second stage
+		process_cmd := gcloud.Run(t, "functions describe", gcloud.WithCommonArgs([]string{chainT.GetStringOutput("process_function_name"), "--project", projectID, "--gen2", "--region", function_location, "--format", "json"}))
+		assert.Equal(outputTopic, fmt.Sprintf("projects/%s/topics/%s", projectID, process_cmd.Get("serviceConfig.environmentVariables.OUTPUT_TOPIC").String()), "The first stage should publish to the topic of the second stage.")
+	})
+	chainT.Test()
+}
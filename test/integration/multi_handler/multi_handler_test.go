@@ -0,0 +1,54 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package multi_handler
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/cloud-foundation-toolkit/infra/blueprint-test/pkg/gcloud"
+	"github.com/GoogleCloudPlatform/cloud-foundation-toolkit/infra/blueprint-test/pkg/tft"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGCF2MultiHandler(t *testing.T) {
+	multiHandlerT := tft.NewTFBlueprintTest(t)
+
+	multiHandlerT.DefineVerify(func(assert *assert.Assertions) {
+		multiHandlerT.DefaultVerify(assert)
+
+		sourceObject := multiHandlerT.GetStringOutput("source_object")
+		projectID := multiHandlerT.GetStringOutput("project_id")
+		function_location := multiHandlerT.GetStringOutput("function_location")
+
+		functions := map[string]string{
+			multiHandlerT.GetStringOutput("created_function_name"):   "HandleOrderCreated",
+			multiHandlerT.GetStringOutput("cancelled_function_name"): "HandleOrderCancelled",
+		}
+
+		for function_name, entryPoint := range functions {
+			function_cmd := gcloud.Run(t, "functions describe", gcloud.WithCommonArgs([]string{function_name, "--project", projectID, "--gen2", "--region", function_location, "--format", "json"}))
+
+			// T01: Verify if the Cloud Functions deployed is in ACTIVE state
+			assert.Equal("ACTIVE", function_cmd.Get("state").String(), fmt.Sprintf("Should be ACTIVE. Cloud Function %s is not successfully deployed.", function_name))
+
+			// T02: Verify if the Cloud Function runs the handler selected by its entry point
+			assert.Equal(entryPoint, function_cmd.Get("buildConfig.entryPoint").String(), fmt.Sprintf("Cloud Function %s entry point should be %s.", function_name, entryPoint))
+
+			// T03: Verify if the Cloud Function is built from the shared source object
+			assert.Equal(sourceObject, function_cmd.Get("buildConfig.source.storageSource.object").String(), fmt.Sprintf("Cloud Function %s should be built from %s.", function_name, sourceObject))
+		}
+	})
+	multiHandlerT.Test()
+}
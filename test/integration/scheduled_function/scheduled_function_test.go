@@ -0,0 +1,67 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package scheduled_function
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/cloud-foundation-toolkit/infra/blueprint-test/pkg/gcloud"
+	"github.com/GoogleCloudPlatform/cloud-foundation-toolkit/infra/blueprint-test/pkg/tft"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGCF2ScheduledFunction(t *testing.T) {
+	scheduledT := tft.NewTFBlueprintTest(t)
+
+	scheduledT.DefineVerify(func(assert *assert.Assertions) {
+		scheduledT.DefaultVerify(assert)
+
+		function_name := scheduledT.GetStringOutput("function_name")
+		function_uri := scheduledT.GetStringOutput("function_uri")
+		jobName := scheduledT.GetStringOutput("scheduler_job_name")
+		schedulerSA := scheduledT.GetStringOutput("scheduler_service_account_email")
+		schedule := scheduledT.GetStringOutput("schedule")
+		projectID := scheduledT.GetStringOutput("project_id")
+		function_location := scheduledT.GetStringOutput("function_location")
+
+		function_cmd := gcloud.Run(t, "functions describe", gcloud.WithCommonArgs([]string{function_name, "--project", projectID, "--gen2", "--region", function_location, "--format", "json"}))
+
+		// T01: Verify if the Cloud Functions deployed is in ACTIVE state
+		assert.Equal("ACTIVE", function_cmd.Get("state").String(), fmt.Sprintf("Should be ACTIVE. Cloud Function is not successfully deployed."))
+
+		job_cmd := gcloud.Run(t, "scheduler jobs describe", gcloud.WithCommonArgs([]string{jobName, "--project", projectID, "--location", function_location, "--format", "json"}))
+
+		// T02: Verify if the Cloud Scheduler job calls the function on the schedule
+		assert.Equal(schedule, job_cmd.Get("schedule").String(), fmt.Sprintf("Cloud Scheduler job schedule should be %s.", schedule))
+		assert.Equal(function_uri, job_cmd.Get("httpTarget.uri").String(), fmt.Sprintf("Cloud Scheduler job should call %s.", function_uri))
+
+		// T03: Verify if the Cloud Scheduler job authenticates with an OIDC token for the function
+		assert.Equal(schedulerSA, job_cmd.Get("httpTarget.oidcToken.serviceAccountEmail").String(), fmt.Sprintf("Cloud Scheduler job OIDC token should use %s.", schedulerSA))
+		assert.Equal(function_uri, job_cmd.Get("httpTarget.oidcToken.audience").String(), fmt.Sprintf("Cloud Scheduler job OIDC token audience should be %s.", function_uri))
+
+		// T04: Verify if the Cloud Scheduler service account can invoke the backing Cloud Run service
+		policy_cmd := gcloud.Run(t, "run services get-iam-policy", gcloud.WithCommonArgs([]string{function_cmd.Get("serviceConfig.service").String(), "--project", projectID, "--region", function_location, "--format", "json"}))
+		invokers := []string{}
+		for _, binding := range policy_cmd.Get("bindings").Array() {
+			if binding.Get("role").String() == "roles/run.invoker" {
+				for _, member := range binding.Get("members").Array() {
+					invokers = append(invokers, member.String())
+				}
+			}
+		}
+		assert.Contains(invokers, fmt.Sprintf("serviceAccount:%s", schedulerSA), "Cloud Scheduler service account should have the Cloud Run Invoker role.")
+	})
+	scheduledT.Test()
+}
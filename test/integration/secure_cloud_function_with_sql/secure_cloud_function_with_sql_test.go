@@ -91,13 +91,15 @@ func TestGCF2CloudSQL(t *testing.T) {
 		assert.Equal(saEmail, cf.Get("serviceConfig.serviceAccountEmail").String(), fmt.Sprintf("Cloud Function should use the service account %s.", saEmail))
 		assert.Equal("google.cloud.pubsub.topic.v1.messagePublished", cf.Get("eventTrigger.eventType").String(), "Event Trigger is not a message published on topic.")
 		assert.Equal(topicID, cf.Get("eventTrigger.pubsubTopic").String(), fmt.Sprintf("Event Trigger topic is not %s.", topicID))
-		assert.Equal("INSTANCE_PWD", cf.Get("serviceConfig.secretEnvironmentVariables.0.key").String(), "Should have secret environment key INSTANCE_PWD")
-		assert.Equal(scrName, cf.Get("serviceConfig.secretEnvironmentVariables.0.secret").String(), fmt.Sprintf("Should have secret environment key %s", scrName))
+		assert.Equal(fmt.Sprintf("projects/%s/secrets/%s/versions/latest", secProjectID, scrName), cf.Get("serviceConfig.environmentVariables.SECRET_NAME").String(), fmt.Sprintf("Should have env var SECRET_NAME with the latest version of secret %s", scrName))
+		assert.False(cf.Get("serviceConfig.environmentVariables.INSTANCE_PWD").Exists(), "Should not have the database password in the environment variables")
+		assert.Empty(cf.Get("serviceConfig.secretEnvironmentVariables").Array(), "Should not have the database password in the secret environment variables")
 		assert.Equal("db-application", cf.Get("serviceConfig.environmentVariables.DATABASE_NAME").String(), "SShould have env var DATABASE_NAME with value db-application")
 		assert.Equal(location, cf.Get("serviceConfig.environmentVariables.INSTANCE_LOCATION").String(), fmt.Sprintf("Should have env var INSTANCE_LOCATION with value %s", location))
 		assert.Equal(mysqlName, cf.Get("serviceConfig.environmentVariables.INSTANCE_NAME").String(), fmt.Sprintf("Should have env var INSTANCE_NAME with value %s", mysqlName))
 		assert.Equal(mysqlUser, cf.Get("serviceConfig.environmentVariables.INSTANCE_USER").String(), fmt.Sprintf("Should have environment var INSTANCE_USER with value %s", mysqlUser))
 		assert.Equal(sqlProjectID, cf.Get("serviceConfig.environmentVariables.INSTANCE_PROJECT_ID").String(), fmt.Sprintf("Should have environment var with value %s", sqlProjectID))
+		assert.Equal(fmt.Sprintf("%s:%s:%s", sqlProjectID, location, mysqlName), cf.Get("serviceConfig.environmentVariables.INSTANCE_CONNECTION_NAME").String(), "Should have env var INSTANCE_CONNECTION_NAME with the connection name of the instance")
 
 		cf = gcloud.Runf(t, "sql instances describe %s --project %s", mysqlName, sqlProjectID)
 		assert.Equal("RUNNABLE", cf.Get("state").String(), "Should be RUNNABLE. Cloud SQL is not successfully deployed.")
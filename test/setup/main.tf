@@ -53,6 +53,9 @@ module "project" {
     "certificatemanager.googleapis.com",
     "sql-component.googleapis.com",
     "sqladmin.googleapis.com",
-    "servicenetworking.googleapis.com"
+    "servicenetworking.googleapis.com",
+    "firestore.googleapis.com",
+    "cloudscheduler.googleapis.com",
+    "secretmanager.googleapis.com"
   ]
 }
@@ -15,8 +15,13 @@
  */
 
 variable "project_id" {
-  description = "Project ID to create Cloud Function"
+  description = "Project ID to create Cloud Function. Every resource of the module is created in this project, the provider default project isn't used"
   type        = string
+
+  validation {
+    condition     = can(regex("^([a-z][a-z0-9.-]*[a-z0-9]:)?[a-z][a-z0-9-]{4,28}[a-z0-9]$", var.project_id))
+    error_message = "The project_id must be a project ID, like my-project, not a project number or an empty string."
+  }
 }
 
 variable "function_name" {
@@ -24,6 +29,28 @@ variable "function_name" {
   type        = string
 }
 
+variable "name_prefix" {
+  description = "Prefix added to the function name, like `dev-`, to deploy the same function in multiple environments"
+  type        = string
+  default     = ""
+
+  validation {
+    condition     = can(regex("^([a-z][a-z0-9-]*)?$", var.name_prefix))
+    error_message = "The name_prefix must start with a lowercase letter and contain only lowercase letters, numbers and hyphens."
+  }
+}
+
+variable "name_suffix" {
+  description = "Suffix added to the function name, like `-dev`, to deploy the same function in multiple environments"
+  type        = string
+  default     = ""
+
+  validation {
+    condition     = can(regex("^[a-z0-9-]*$", var.name_suffix))
+    error_message = "The name_suffix must contain only lowercase letters, numbers and hyphens."
+  }
+}
+
 variable "function_location" {
   description = "The location of this cloud function"
   type        = string
@@ -41,6 +68,30 @@ variable "labels" {
   default     = null
 }
 
+variable "deployment_metadata" {
+  description = "Deployment metadata, like the git commit SHA or the build number, added to the labels of the function. The values are lowercased and invalid characters replaced by hyphens. Changing a value deploys a new revision"
+  type        = map(string)
+  default     = {}
+
+  validation {
+    condition     = alltrue([for key in keys(var.deployment_metadata) : can(regex("^[a-z][a-z0-9_-]{0,62}$", key))])
+    error_message = "The deployment_metadata keys must start with a lowercase letter and have at most 63 lowercase letters, numbers, underscores and hyphens."
+  }
+}
+
+variable "trigger_labels" {
+  description = "A set of key/value label pairs associated with the Eventarc trigger, merged with the labels. Only used when the module manages the trigger, with an event_trigger transport_topic"
+  type        = map(string)
+  default     = {}
+
+  validation {
+    condition = alltrue([
+      for key, value in var.trigger_labels : can(regex("^[a-z][a-z0-9_-]{0,62}$", key)) && can(regex("^[a-z0-9_-]{0,63}$", value))
+    ])
+    error_message = "The trigger_labels keys must start with a lowercase letter and have at most 63 lowercase letters, numbers, underscores and hyphens, and the values must have at most 63 of the same characters."
+  }
+}
+
 variable "runtime" {
   description = "The runtime in which to run the function."
   type        = string
@@ -57,10 +108,94 @@ variable "build_env_variables" {
   default     = null
 }
 
+variable "buildpack_config" {
+  description = "Google Cloud buildpacks settings, set as the `GOOGLE_*` build-time environment variables. The function_target and function_signature_type default to the entrypoint and to `http` or `cloudevent` for functions with an event_trigger. The build_env_variables take precedence on duplicated keys"
+  type = object({
+    runtime_version         = optional(string)
+    buildable               = optional(string)
+    go_gcflags              = optional(string)
+    go_ldflags              = optional(string)
+    clear_source            = optional(bool)
+    function_target         = optional(string)
+    function_signature_type = optional(string)
+  })
+  default = {}
+
+  validation {
+    condition     = try(var.buildpack_config.function_signature_type, null) == null ? true : contains(["http", "event", "cloudevent"], var.buildpack_config.function_signature_type)
+    error_message = "The buildpack_config function_signature_type must be http, event or cloudevent."
+  }
+}
+
+variable "serialize_builds" {
+  description = "Set to true to wait, using the Google Cloud CLI, for an in-progress deployment of the function to finish before starting a new build, like one left running by a timed out apply, so builds don't pile up"
+  type        = bool
+  default     = false
+}
+
+variable "require_approval_token" {
+  description = "Set to true to run the approval_check_command before every deployment of the function, failing the apply unless it accepts the approval_token, like a change approval of a change-management system"
+  type        = bool
+  default     = false
+}
+
+variable "approval_token" {
+  description = "Approval token checked by the approval_check_command when require_approval_token is true, like a change request ID"
+  type        = string
+  default     = null
+  sensitive   = true
+}
+
+variable "approval_check_command" {
+  description = "Command run where Terraform runs to check the approval_token, which must exit with a non-zero status to reject the deployment. It gets the APPROVAL_TOKEN, FUNCTION_NAME, FUNCTION_PROJECT, FUNCTION_LOCATION and DEPLOY_INPUTS_HASH environment variables"
+  type        = string
+  default     = null
+}
+
+variable "rollback_on_failure" {
+  description = "Set to true to check each deployment with the Google Cloud CLI once it is applied, failing the apply when the function isn't ACTIVE or its latest revision isn't ready. The backing Cloud Run service keeps serving its latest ready revision"
+  type        = bool
+  default     = false
+}
+
+variable "tag_source_with_revision" {
+  description = "Set to true to write, using the Google Cloud CLI, the name of the backing Cloud Run revision deployed from the source object in its `cloud-run-revision` custom metadata"
+  type        = bool
+  default     = false
+}
+
+variable "retain_source_on_destroy" {
+  description = "Set to true to copy, using the Google Cloud CLI, every source object deployed to the source_retention_bucket, so the source that ran is kept after the function and its source are destroyed. Requires a storage_source, url_source or inline_source"
+  type        = bool
+  default     = false
+}
+
+variable "source_retention_bucket" {
+  description = "Name of the bucket where the source objects are copied when retain_source_on_destroy is true, under a `<FUNCTION-NAME>/` prefix. Use a bucket with a retention policy to prevent the copies from being deleted"
+  type        = string
+  default     = null
+}
+
 variable "worker_pool" {
-  description = "Name of the Cloud Build Custom Worker Pool that should be used to build the function."
+  description = "Name of the Cloud Build Custom Worker Pool that should be used to build the function, in the format `projects/<PROJECT>/locations/<REGION>/workerPools/<POOL>`. The pool can be in another region or project than the function"
   type        = string
   default     = null
+
+  validation {
+    condition     = var.worker_pool == null ? true : can(regex("^projects/[^/]+/locations/[^/]+/workerPools/[^/]+$", var.worker_pool))
+    error_message = "The worker_pool must be in the format projects/<PROJECT>/locations/<REGION>/workerPools/<POOL>."
+  }
+}
+
+variable "worker_pool_project_id" {
+  description = "ID of the project of the worker_pool, when it is in another project than the function. The Cloud Build service account and the Cloud Functions service agent of the function project are granted the Cloud Build WorkerPool User role on it"
+  type        = string
+  default     = null
+
+  validation {
+    condition     = var.worker_pool_project_id == null ? true : can(regex("^([a-z][a-z0-9.-]*[a-z0-9]:)?[a-z][a-z0-9-]{4,28}[a-z0-9]$", var.worker_pool_project_id))
+    error_message = "The worker_pool_project_id must be a project ID, like my-project."
+  }
 }
 
 variable "docker_repository" {
@@ -70,13 +205,47 @@ variable "docker_repository" {
 }
 
 variable "storage_source" {
-  description = "Get the source from this location in Google Cloud Storage"
+  description = "Get the source from this location in Google Cloud Storage. Set `generation` to pin the deploy to a specific object generation."
   type = object({
     bucket     = string
     object     = string
     generation = optional(string, null)
   })
   default = null
+
+  validation {
+    condition     = var.storage_source == null ? true : var.storage_source.generation == null ? true : can(regex("^[1-9][0-9]*$", var.storage_source.generation))
+    error_message = "The storage_source generation must be a positive integer."
+  }
+}
+
+variable "url_source" {
+  description = "Get the source from a zip archive at this URL, like a generic artifact store. The archive is downloaded, verified against the `sha256` checksum and uploaded to the `bucket` using the Google Cloud CLI"
+  type = object({
+    url    = string
+    sha256 = string
+    bucket = string
+  })
+  default = null
+
+  validation {
+    condition     = var.url_source == null ? true : can(regex("^https?://", var.url_source.url)) && can(regex("^[a-f0-9]{64}$", var.url_source.sha256))
+    error_message = "The url_source url must be an HTTP(S) URL and the sha256 must be a lowercase hex encoded SHA-256 checksum."
+  }
+}
+
+variable "inline_source" {
+  description = "Source files of a small function, as a map of file name to content, zipped and uploaded to the `bucket`. A go.mod is generated for Go runtimes when not provided"
+  type = object({
+    files  = map(string)
+    bucket = string
+  })
+  default = null
+
+  validation {
+    condition     = var.inline_source == null ? true : length(var.inline_source.files) > 0
+    error_message = "The inline_source files must contain at least one file."
+  }
 }
 
 variable "repo_source" {
@@ -94,12 +263,13 @@ variable "repo_source" {
 }
 
 variable "event_trigger" {
-  description = "Event triggers for the function"
+  description = "Event triggers for the function. Set `transport_topic` to use an existing Pub/Sub topic as the Eventarc transport instead of an Eventarc managed one, which Eventarc only supports for the google.cloud.pubsub.topic.v1.messagePublished event type."
   type = object({
     trigger_region        = optional(string)
     event_type            = string
     service_account_email = string
     pubsub_topic          = optional(string)
+    transport_topic       = optional(string)
     retry_policy          = string
     event_filters = optional(set(object({
       attribute       = string
@@ -108,16 +278,40 @@ variable "event_trigger" {
     })))
   })
   default = null
+
+  validation {
+    condition     = var.event_trigger == null ? true : var.event_trigger.transport_topic == null || var.event_trigger.event_type == "google.cloud.pubsub.topic.v1.messagePublished"
+    error_message = "The event_trigger transport_topic is only supported by Eventarc for the google.cloud.pubsub.topic.v1.messagePublished event type. Other triggers, like the Cloud Audit Logs and Cloud Storage ones, use an Eventarc managed topic."
+  }
+}
+
+variable "manage_eventarc_iam" {
+  description = "Set to true to grant the roles needed by Cloud Storage triggers, direct or through Cloud Audit Logs: the Pub/Sub Publisher role to the Cloud Storage service agent, the Eventarc Service Agent role to the Eventarc service agent and the Eventarc Event Receiver role to the trigger service account"
+  type        = bool
+  default     = false
+}
+
+variable "resource_profile" {
+  description = "Bundle of memory, CPU, concurrency, timeout and maximum instances used by the service_config fields which are not set. Possible values: [\"small\", \"medium\", \"large\"]"
+  type        = string
+  default     = null
+
+  validation {
+    condition     = var.resource_profile == null ? true : contains(["small", "medium", "large"], var.resource_profile)
+    error_message = "The resource_profile must be small, medium or large."
+  }
 }
 
 variable "service_config" {
-  description = "Details of the service"
+  description = "Details of the service. The max_instance_count, min_instance_count, available_memory, available_cpu, max_instance_request_concurrency and timeout_seconds not set use the resource_profile. Without a profile, the max_instance_count, min_instance_count, available_memory and timeout_seconds default to 100, 1, 256M and 60, and the CPU and the concurrency are derived by the platform from the memory"
   type = object({
-    max_instance_count    = optional(string, 100)
-    min_instance_count    = optional(string, 1)
-    available_memory      = optional(string, "256M")
-    timeout_seconds       = optional(string, 60)
-    runtime_env_variables = optional(map(string), null)
+    max_instance_count               = optional(string)
+    min_instance_count               = optional(string)
+    available_memory                 = optional(string)
+    available_cpu                    = optional(string)
+    max_instance_request_concurrency = optional(string)
+    timeout_seconds                  = optional(string)
+    runtime_env_variables            = optional(map(string), null)
     runtime_secret_env_variables = optional(set(object({
       key_name   = string
       project_id = optional(string)
@@ -140,12 +334,272 @@ variable "service_config" {
     all_traffic_on_latest_revision = optional(bool, true)
   })
   default = {}
+
+  validation {
+    condition     = alltrue([for secret in concat([for env in(var.service_config == null ? [] : var.service_config.runtime_secret_env_variables == null ? [] : tolist(var.service_config.runtime_secret_env_variables)) : env.secret], [for volume in(var.service_config == null ? [] : var.service_config.secret_volumes == null ? [] : tolist(var.service_config.secret_volumes)) : volume.secret]) : can(regex("^[a-zA-Z0-9_-]{1,255}$", secret))])
+    error_message = "The secret of the runtime_secret_env_variables and secret_volumes must be a secret name, not the full secret ID, with at most 255 letters, numbers, underscores and hyphens."
+  }
+
+  validation {
+    condition     = alltrue([for version in concat([for env in(var.service_config == null ? [] : var.service_config.runtime_secret_env_variables == null ? [] : tolist(var.service_config.runtime_secret_env_variables)) : env.version], flatten([for volume in(var.service_config == null ? [] : var.service_config.secret_volumes == null ? [] : tolist(var.service_config.secret_volumes)) : [for v in volume.versions : v.version]])) : can(regex("^(latest|[1-9][0-9]*)$", version))])
+    error_message = "The version of the runtime_secret_env_variables and secret_volumes must be latest or a positive integer."
+  }
+
+  validation {
+    condition     = alltrue([for project in concat([for env in(var.service_config == null ? [] : var.service_config.runtime_secret_env_variables == null ? [] : tolist(var.service_config.runtime_secret_env_variables)) : env.project_id], [for volume in(var.service_config == null ? [] : var.service_config.secret_volumes == null ? [] : tolist(var.service_config.secret_volumes)) : volume.project_id]) : project == null ? true : can(regex("^(([a-z][a-z0-9.-]*[a-z0-9]:)?[a-z][a-z0-9-]{4,28}[a-z0-9]|[0-9]+)$", project))])
+    error_message = "The project_id of the runtime_secret_env_variables and secret_volumes must be a project ID or number. Leave it unset to use the function project."
+  }
+}
+
+variable "keep_warm" {
+  description = "Keep at least one instance of the function warm to avoid cold starts. Requires the service_config min_instance_count to be at least 1. The keep-warm submodule can also call the function on a schedule"
+  type        = bool
+  default     = false
+}
+
+variable "inject_metadata_env" {
+  description = "Set to true to add the GCP_PROJECT, FUNCTION_REGION and FUNCTION_NAME runtime environment variables with the project ID, location and name of the function. They can be overridden by the other runtime environment variables"
+  type        = bool
+  default     = false
+}
+
+variable "environment_variables_layers" {
+  description = "Runtime environment variables merged in order, later layers taking precedence, like a base map followed by per-environment overrides. The environment_variables_any and the service_config runtime_env_variables are merged after the layers"
+  type        = list(map(string))
+  default     = []
+}
+
+variable "environment_variables_any" {
+  description = "Runtime environment variables with number or bool values, like `MAX_CONN = 10`, converted to strings. The service_config runtime_env_variables take precedence on duplicated keys"
+  type        = map(any)
+  default     = {}
+}
+
+variable "execution_environment" {
+  description = "The execution environment of the backing Cloud Run service. Possible values: [\"EXECUTION_ENVIRONMENT_GEN1\", \"EXECUTION_ENVIRONMENT_GEN2\"]. Defaults to the provider default."
+  type        = string
+  default     = null
+
+  validation {
+    condition     = var.execution_environment == null ? true : contains(["EXECUTION_ENVIRONMENT_GEN1", "EXECUTION_ENVIRONMENT_GEN2"], var.execution_environment)
+    error_message = "The execution_environment must be EXECUTION_ENVIRONMENT_GEN1 or EXECUTION_ENVIRONMENT_GEN2."
+  }
+}
+
+variable "nfs_volumes" {
+  description = "NFS or Filestore shares to mount on the backing Cloud Run service. Requires the `EXECUTION_ENVIRONMENT_GEN2` execution environment."
+  type = list(object({
+    server     = string
+    path       = string
+    mount_path = string
+    read_only  = optional(bool, false)
+  }))
+  default = []
+
+  validation {
+    condition     = alltrue([for nfs in var.nfs_volumes : can(regex("^/", nfs.path)) && can(regex("^/", nfs.mount_path))])
+    error_message = "The NFS volume path and mount_path must be absolute paths."
+  }
+}
+
+variable "revision_suffix" {
+  description = "Suffix of the backing Cloud Run service revision name, which will be named `<FUNCTION-NAME>-<SUFFIX>-<HASH>`. The hash is derived from the function update time, so every deploy gets an unique revision name. Defaults to a generated suffix."
+  type        = string
+  default     = null
+
+  validation {
+    condition     = var.revision_suffix == null ? true : can(regex("^[a-z0-9]([-a-z0-9]*[a-z0-9])?$", var.revision_suffix))
+    error_message = "The revision_suffix must contain only lowercase letters, numbers and hyphens, and must start and end with a letter or number."
+  }
+}
+
+variable "revision_labels" {
+  description = "A set of key/value label pairs set on the backing Cloud Run service revisions, like a release channel selected by canary tooling. gcloud applies them to the Cloud Run service too, but not to the function"
+  type        = map(string)
+  default     = {}
+
+  validation {
+    condition = alltrue([
+      for key, value in var.revision_labels : can(regex("^[a-z][a-z0-9_-]{0,62}$", key)) && can(regex("^[a-z0-9_-]{0,63}$", value))
+    ])
+    error_message = "The revision_labels keys must start with a lowercase letter and have at most 63 lowercase letters, numbers, underscores and hyphens, and the values must have at most 63 of the same characters."
+  }
+}
+
+variable "container_port" {
+  description = "Port the function container listens on, set on the backing Cloud Run service and in the PORT environment variable, for containers which don't use the default 8080. Defaults to the framework default"
+  type        = number
+  default     = null
+
+  validation {
+    condition     = var.container_port == null ? true : var.container_port >= 1 && var.container_port <= 65535 && floor(var.container_port) == var.container_port
+    error_message = "The container_port must be an integer between 1 and 65535."
+  }
+}
+
+variable "traffic_split" {
+  description = "Percent of the traffic sent to each revision of the backing Cloud Run service, like `{ \"function-00002-abc\" = 90, LATEST = 10 }` for a canary, where LATEST is the latest revision. The percentages must sum to 100. Requires the service_config all_traffic_on_latest_revision to be false"
+  type        = map(number)
+  default     = {}
+
+  validation {
+    condition     = length(var.traffic_split) == 0 || (sum(concat([0], values(var.traffic_split))) == 100 && alltrue([for percent in values(var.traffic_split) : percent >= 0 && percent <= 100 && floor(percent) == percent]))
+    error_message = "The traffic_split percentages must be integers between 0 and 100 which sum to 100."
+  }
+}
+
+variable "cpu_always_allocated" {
+  description = "Keep the CPU of the backing Cloud Run service allocated outside of requests, needed for background work after the response is sent. Requires the service_config min_instance_count to be at least 1. Defaults to CPU allocated only during requests"
+  type        = bool
+  default     = false
+}
+
+variable "post_response_work" {
+  description = "Set to true for functions doing background work after the response is sent. Requires cpu_always_allocated and a service_config min_instance_count of at least 1, so the work isn't throttled or lost when the instances scale down"
+  type        = bool
+  default     = false
+}
+
+variable "tmp_volume_size_limit" {
+  description = "Size limit of an in-memory volume mounted at /tmp on the backing Cloud Run service, like `512Mi`. Counts against the service_config available_memory. Defaults to the writable in-memory file system without a limit"
+  type        = string
+  default     = null
+
+  validation {
+    condition     = var.tmp_volume_size_limit == null ? true : can(regex("^[1-9][0-9]*(M|Mi|G|Gi)$", var.tmp_volume_size_limit))
+    error_message = "The tmp_volume_size_limit must be a size with a M, Mi, G or Gi unit, like 512Mi."
+  }
+}
+
+variable "startup_probe" {
+  description = "TCP startup probe of the backing Cloud Run service, for functions which take long to initialize. The fields not set use the Cloud Run defaults. Defaults to the Cloud Run default startup probe"
+  type = object({
+    initial_delay_seconds = optional(number)
+    timeout_seconds       = optional(number)
+    period_seconds        = optional(number)
+    failure_threshold     = optional(number)
+  })
+  default = null
+
+  validation {
+    condition = var.startup_probe == null ? true : alltrue([
+      try(var.startup_probe.initial_delay_seconds >= 0 && var.startup_probe.initial_delay_seconds <= 240, true),
+      try(var.startup_probe.timeout_seconds >= 1 && var.startup_probe.timeout_seconds <= 240, true),
+      try(var.startup_probe.period_seconds >= 1 && var.startup_probe.period_seconds <= 240, true),
+      try(var.startup_probe.failure_threshold >= 1, true),
+      try(var.startup_probe.timeout_seconds <= var.startup_probe.period_seconds, true),
+    ])
+    error_message = "The startup_probe initial_delay_seconds must be between 0 and 240, the timeout_seconds and period_seconds between 1 and 240, the failure_threshold at least 1 and the timeout_seconds can't be greater than the period_seconds."
+  }
+}
+
+variable "container_startup_timeout_seconds" {
+  description = "Time the function container has to start listening on its port, in seconds, for functions with heavy initialization like loading a model. It sets the failure_threshold of the startup_probe, which probes every period_seconds, 10 by default. Maximum of 3600 seconds. Defaults to the Cloud Run default startup probe"
+  type        = number
+  default     = null
+
+  validation {
+    condition     = var.container_startup_timeout_seconds == null ? true : var.container_startup_timeout_seconds >= 1 && var.container_startup_timeout_seconds <= 3600 && floor(var.container_startup_timeout_seconds) == var.container_startup_timeout_seconds
+    error_message = "The container_startup_timeout_seconds must be an integer between 1 and 3600."
+  }
+}
+
+variable "health_check_path" {
+  description = "HTTP path, like `/healthz`, of the liveness probe of the backing Cloud Run service. Instances failing the probe are restarted. Defaults to no liveness probe"
+  type        = string
+  default     = null
+
+  validation {
+    condition     = var.health_check_path == null ? true : can(regex("^/[^\\s,]*$", var.health_check_path))
+    error_message = "The health_check_path must start with a slash and can't contain whitespaces or commas."
+  }
+}
+
+variable "liveness_probe" {
+  description = "Thresholds of the liveness probe on the health_check_path. The fields not set use the Cloud Run defaults"
+  type = object({
+    initial_delay_seconds = optional(number)
+    timeout_seconds       = optional(number)
+    period_seconds        = optional(number)
+    failure_threshold     = optional(number)
+  })
+  default = {}
+
+  validation {
+    condition = alltrue([
+      try(var.liveness_probe.initial_delay_seconds >= 0 && var.liveness_probe.initial_delay_seconds <= 3600, true),
+      try(var.liveness_probe.timeout_seconds >= 1 && var.liveness_probe.timeout_seconds <= 3600, true),
+      try(var.liveness_probe.period_seconds >= 1 && var.liveness_probe.period_seconds <= 3600, true),
+      try(var.liveness_probe.failure_threshold >= 1, true),
+      try(var.liveness_probe.timeout_seconds <= var.liveness_probe.period_seconds, true),
+    ])
+    error_message = "The liveness_probe initial_delay_seconds must be between 0 and 3600, the timeout_seconds and period_seconds between 1 and 3600, the failure_threshold at least 1 and the timeout_seconds can't be greater than the period_seconds."
+  }
+}
+
+variable "streaming_timeout_seconds" {
+  description = "Request timeout of the backing Cloud Run service, in seconds, for HTTP functions with long-lived streaming responses, like server-streaming gRPC. Maximum of 3600 seconds. Defaults to the service_config timeout_seconds."
+  type        = number
+  default     = null
+
+  validation {
+    condition     = var.streaming_timeout_seconds == null ? true : var.streaming_timeout_seconds >= 1 && var.streaming_timeout_seconds <= 3600 && floor(var.streaming_timeout_seconds) == var.streaming_timeout_seconds
+    error_message = "The streaming_timeout_seconds must be an integer between 1 and 3600."
+  }
+}
+
+variable "binary_authorization_breakglass_justification" {
+  description = "Justification to deploy the backing Cloud Run service bypassing the Binary Authorization policy enforced on the project, for emergency deploys. Breakglass deploys are recorded in the Cloud Audit Logs. Defaults to enforcing the policy"
+  type        = string
+  default     = null
+
+  validation {
+    condition     = var.binary_authorization_breakglass_justification == null ? true : length(trimspace(var.binary_authorization_breakglass_justification)) > 0
+    error_message = "The binary_authorization_breakglass_justification must not be empty."
+  }
+}
+
+variable "cloudsql_instances" {
+  description = "Connection names, like `<PROJECT-ID>:<REGION>:<INSTANCE>`, of the Cloud SQL instances attached to the backing Cloud Run service. Each instance is reachable by the function through a Unix socket at `/cloudsql/<CONNECTION-NAME>`"
+  type        = list(string)
+  default     = []
+
+  validation {
+    condition     = alltrue([for instance in var.cloudsql_instances : can(regex("^([a-z][a-z0-9.-]*:)?[a-z][a-z0-9-]{4,28}[a-z0-9]:[a-z]+-[a-z]+[0-9]+:[a-z][a-z0-9-]{0,97}$", instance))])
+    error_message = "The cloudsql_instances must be instance connection names, like <PROJECT-ID>:<REGION>:<INSTANCE>."
+  }
+}
+
+variable "write_manifest" {
+  description = "Set to true to write a JSON manifest of the resolved function configuration, like the name, trigger, service account, environment variable keys and scaling, to the manifest_path. Secret and environment variable values are not written"
+  type        = bool
+  default     = false
+}
+
+variable "manifest_path" {
+  description = "Path of the manifest written when write_manifest is true. Defaults to `<FUNCTION-NAME>-manifest.json` in the root module directory"
+  type        = string
+  default     = null
+}
+
+variable "audit_log_config" {
+  description = "Data Access audit log types, like DATA_READ and DATA_WRITE, enabled for the Cloud Run API in the project, and the members exempted from them. Audit configs are set per project and service, so they apply to all the Cloud Run services and functions of the project. Defaults to not changing the audit config"
+  type = object({
+    log_types        = list(string)
+    exempted_members = optional(list(string), [])
+  })
+  default = null
+
+  validation {
+    condition     = var.audit_log_config == null ? true : length(var.audit_log_config.log_types) > 0 && alltrue([for log_type in var.audit_log_config.log_types : contains(["ADMIN_READ", "DATA_READ", "DATA_WRITE"], log_type)])
+    error_message = "The audit_log_config log_types must be one or more of ADMIN_READ, DATA_READ and DATA_WRITE."
+  }
 }
 
 // IAM
 variable "members" {
   type        = map(list(string))
-  description = "Cloud Function Invoker and Developer roles for Users/SAs. Key names must be developers and/or invokers"
+  description = "Cloud Function Invoker and Developer roles for Users/SAs. Key names must be developers and/or invokers. Workload Identity Federation principal:// and principalSet:// invokers are granted the Cloud Run Invoker role too"
   default     = {}
   validation {
     condition = alltrue([
@@ -153,4 +607,23 @@ variable "members" {
     ])
     error_message = "The supported keys are invokers and developers."
   }
+
+  validation {
+    condition = alltrue([
+      for member in flatten(values(var.members)) : !can(regex("^principal", member)) || can(regex("^principal://iam\\.googleapis\\.com/projects/[0-9]+/locations/global/workloadIdentityPools/[a-z0-9-]+/subject/.+$", member)) || can(regex("^principalSet://iam\\.googleapis\\.com/projects/[0-9]+/locations/global/workloadIdentityPools/[a-z0-9-]+/(group/.+|attribute\\.[a-zA-Z0-9_]+/.+|\\*)$", member))
+    ])
+    error_message = "Workload Identity Federation members must be principal://iam.googleapis.com/projects/<PROJECT-NUMBER>/locations/global/workloadIdentityPools/<POOL>/subject/<SUBJECT> or principalSet://iam.googleapis.com/projects/<PROJECT-NUMBER>/locations/global/workloadIdentityPools/<POOL>/attribute.<ATTRIBUTE>/<VALUE>, with group/<GROUP> or * instead of the attribute."
+  }
+}
+
+variable "require_authentication" {
+  description = "Require authentication to invoke the function, rejecting allUsers and allAuthenticatedUsers in the invokers members. Set to false to allow public functions"
+  type        = bool
+  default     = true
+}
+
+variable "mark_uri_sensitive" {
+  description = "Set to true to mark the function URI and the outputs built from it, like the invoke and curl commands, as sensitive so they are redacted from the plan and apply logs"
+  type        = bool
+  default     = false
 }
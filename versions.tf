@@ -25,6 +25,18 @@ terraform {
       source  = "hashicorp/google-beta"
       version = "< 5.0"
     }
+    null = {
+      source  = "hashicorp/null"
+      version = "3.2.0"
+    }
+    local = {
+      source  = "hashicorp/local"
+      version = "2.4.0"
+    }
+    archive = {
+      source  = "hashicorp/archive"
+      version = "2.4.0"
+    }
   }
 
   provider_meta "google" {